@@ -0,0 +1,301 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	AttributeGroupTypeAtLeastOneOf  = "atLeastOneOf"
+	AttributeGroupTypeConflictsWith = "conflictsWith"
+	AttributeGroupTypeExactlyOneOf  = "exactlyOneOf"
+	AttributeGroupTypeRequiredWith  = "requiredWith"
+)
+
+// AttributeGroup is a set of properties of the same object which must be configured together.
+//
+// For atLeastOneOf, exactlyOneOf and conflictsWith groups, at least one, exactly one or at most one
+// of the Pointers may be configured. For requiredWith groups, when the first of the Pointers is
+// configured all the others must also be configured.
+type AttributeGroup struct {
+	Object   PropertyJsonPointer
+	Pointers PropertyJsonPointers
+	Type     string
+}
+
+// String returns a string representation of AttributeGroup.
+func (g *AttributeGroup) String() string {
+	if g == nil {
+		return ""
+	}
+
+	var pointers []string
+
+	for _, pointer := range g.Pointers {
+		pointers = append(pointers, pointer.String())
+	}
+
+	return fmt.Sprintf("%s %s: %s", g.Type, g.Object, strings.Join(pointers, ", "))
+}
+
+// AttributeGroups is a list of AttributeGroup.
+type AttributeGroups []*AttributeGroup
+
+// ByObject returns the groups keyed by object.
+func (gs AttributeGroups) ByObject() map[PropertyJsonPointer]AttributeGroups {
+	m := make(map[PropertyJsonPointer]AttributeGroups)
+
+	for _, g := range gs {
+		m[g.Object] = append(m[g.Object], g)
+	}
+
+	return m
+}
+
+// String returns a string representation of AttributeGroups, one per line.
+func (gs AttributeGroups) String() string {
+	var lines []string
+
+	for _, g := range gs {
+		lines = append(lines, g.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// AttributeGroups returns the groups of properties that must be configured together, for the top-level
+// object (/properties) and every nested object, with array items as the * wildcard, e.g. /properties/Rules/*,
+// derived from:
+//
+//   - oneOf subschemas, including those already unwrapped by UnwrapOneOfProperties, as exactlyOneOf groups,
+//     or conflictsWith groups when not every subschema requires a property
+//   - anyOf subschemas as atLeastOneOf groups
+//   - dependencies property lists as requiredWith groups
+//
+// Properties required by every subschema are not part of a group. Where a subschema requires several
+// properties, its first required property represents it in the group and a requiredWith group is added
+// for each of its required properties. Subschemas of allOf always apply and are analyzed in turn.
+func (r *Resource) AttributeGroups() AttributeGroups {
+	if r == nil {
+		return nil
+	}
+
+	a := &attributeGroupAnalyzer{
+		resource:     r,
+		visiting:     make(map[*Property]struct{}),
+		visitingRefs: make(map[Reference]struct{}),
+	}
+
+	a.analyzeProperty(&Property{
+		AllOf:      r.AllOf,
+		AnyOf:      r.AnyOf,
+		OneOf:      r.OneOf,
+		Properties: r.Properties,
+		Required:   r.Required,
+	}, nil)
+
+	return a.groups
+}
+
+// attributeGroupAnalyzer accumulates groups while walking the properties of a Resource.
+type attributeGroupAnalyzer struct {
+	groups       AttributeGroups
+	resource     *Resource
+	visiting     map[*Property]struct{}
+	visitingRefs map[Reference]struct{}
+}
+
+// analyzeProperty adds the groups of the Property and of all its nested properties.
+func (a *attributeGroupAnalyzer) analyzeProperty(property *Property, path []string) {
+	if property == nil {
+		return
+	}
+
+	// Properties resolved from other documents are new on each resolution, so also track the references.
+	if ref := propertyReference(property); ref != nil {
+		if _, ok := a.visitingRefs[*ref]; ok {
+			return
+		}
+
+		a.visitingRefs[*ref] = struct{}{}
+		defer delete(a.visitingRefs, *ref)
+	}
+
+	property = a.resource.resolvedProperty(property)
+
+	if property == nil {
+		return
+	}
+
+	if _, ok := a.visiting[property]; ok {
+		return
+	}
+
+	a.visiting[property] = struct{}{}
+	defer delete(a.visiting, property)
+
+	a.analyzeProperty(property.Items, appendPath(path, PropertyJsonPointerWildcard))
+
+	a.analyzeSubschemas(path, property.AllOf, property.AnyOf, property.OneOf)
+	a.analyzeSubschemas(path, nil, nil, property.UnwrappedOneOf)
+
+	for _, name := range sortedDependencyNames(property.Dependencies) {
+		dependency := property.Dependencies[name]
+
+		if dependency == nil || dependency.IsSchema() || len(dependency.Properties) == 0 {
+			continue
+		}
+
+		a.addGroup(AttributeGroupTypeRequiredWith, path, append([]string{name}, dependency.Properties...))
+	}
+
+	for _, name := range sortedPropertyNames(property.Properties) {
+		a.analyzeProperty(property.Properties[name], appendPath(path, name))
+	}
+}
+
+// analyzeSubschemas adds the groups of the compositions of an object.
+func (a *attributeGroupAnalyzer) analyzeSubschemas(path []string, allOf, anyOf, oneOf []*PropertySubschema) {
+	for _, subschema := range allOf {
+		if subschema == nil {
+			continue
+		}
+
+		a.analyzeSubschemas(path, subschema.AllOf, subschema.AnyOf, subschema.OneOf)
+	}
+
+	a.analyzeAlternatives(AttributeGroupTypeAtLeastOneOf, path, anyOf)
+	a.analyzeAlternatives(AttributeGroupTypeExactlyOneOf, path, oneOf)
+}
+
+// analyzeAlternatives adds the groups of a list of anyOf or oneOf subschemas.
+func (a *attributeGroupAnalyzer) analyzeAlternatives(typ string, path []string, subschemas []*PropertySubschema) {
+	if len(subschemas) < 2 {
+		return
+	}
+
+	var common []string
+
+	branches := make([][]string, len(subschemas))
+
+	for i, subschema := range subschemas {
+		branches[i] = subschemaRequired(subschema)
+
+		if i == 0 {
+			common = branches[i]
+		} else {
+			common = intersectStrings(common, branches[i])
+		}
+	}
+
+	var names []string
+	var requiredWith [][]string
+
+	unconstrained := false
+
+	for i, subschema := range subschemas {
+		branch := subtractStrings(branches[i], common)
+
+		if len(branch) == 0 {
+			// An anyOf subschema requiring nothing more is always satisfied.
+			if typ == AttributeGroupTypeAtLeastOneOf {
+				return
+			}
+
+			// A oneOf subschema requiring nothing more allows none of the alternatives.
+			typ = AttributeGroupTypeConflictsWith
+			unconstrained = true
+
+			if subschema != nil {
+				branch = subtractStrings(sortedPropertyNames(subschema.Properties), common)
+			}
+
+			if len(branch) == 0 {
+				continue
+			}
+		}
+
+		names = append(names, branch[0])
+
+		if len(branches[i]) == 0 {
+			continue
+		}
+
+		for j := range branch {
+			requiredWith = append(requiredWith, append([]string{branch[j]}, subtractStrings(branch, branch[j:j+1])...))
+		}
+	}
+
+	// Groups are only added once every subschema is checked, as a subschema requiring nothing more
+	// means the properties required by the others need not be configured together.
+	if !unconstrained {
+		for _, names := range requiredWith {
+			a.addGroup(AttributeGroupTypeRequiredWith, path, names)
+		}
+	}
+
+	a.addGroup(typ, path, names)
+}
+
+// addGroup adds a group of the named properties of the object at path, if there are at least two.
+func (a *attributeGroupAnalyzer) addGroup(typ string, path []string, names []string) {
+	if len(names) < 2 {
+		return
+	}
+
+	group := &AttributeGroup{
+		Object: NewPropertyJsonPointer(path...),
+		Type:   typ,
+	}
+
+	for _, name := range names {
+		group.Pointers = append(group.Pointers, NewPropertyJsonPointer(appendPath(path, name)...))
+	}
+
+	a.groups = append(a.groups, group)
+}
+
+// sortedDependencyNames returns the sorted names of a dependencies map.
+func sortedDependencyNames(dependencies map[string]*PropertyDependency) []string {
+	names := make([]string, 0, len(dependencies))
+
+	for name := range dependencies {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// subschemaRequired returns the properties required by a subschema, including by its allOf subschemas.
+func subschemaRequired(subschema *PropertySubschema) []string {
+	if subschema == nil {
+		return nil
+	}
+
+	required := cloneSlice(subschema.Required)
+
+	for _, s := range subschema.AllOf {
+		required = unionStrings(required, subschemaRequired(s))
+	}
+
+	return required
+}
+
+// subtractStrings returns the values of a, in order, that are not in b.
+func subtractStrings(a, b []string) []string {
+	var values []string
+
+	for _, v := range a {
+		if len(intersectStrings([]string{v}, b)) == 0 {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
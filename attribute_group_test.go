@@ -0,0 +1,122 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestResourceAttributeGroups(t *testing.T) {
+	testCases := []struct {
+		TestDescription    string
+		ResourceSchemaPath string
+		Schema             string
+		Expand             bool
+		Expected           []string
+	}{
+		{
+			TestDescription:    "resource allOf oneOf",
+			ResourceSchemaPath: "AWS_GameLift_Fleet.json",
+			Expected: []string{
+				"exactlyOneOf /properties: /properties/BuildId, /properties/ScriptId",
+				"requiredWith /properties: /properties/ServerLaunchParameters, /properties/ServerLaunchPath",
+				"requiredWith /properties: /properties/ServerLaunchPath, /properties/ServerLaunchParameters",
+				"exactlyOneOf /properties: /properties/RuntimeConfiguration, /properties/ServerLaunchParameters",
+			},
+		},
+		{
+			TestDescription:    "resource anyOf",
+			ResourceSchemaPath: "AWS_CloudWatch_MetricStream.json",
+		},
+		{
+			TestDescription:    "nested anyOf and oneOf",
+			ResourceSchemaPath: "AWS_S3Outposts_Bucket.json",
+			Expected: []string{
+				"atLeastOneOf /properties/LifecycleConfiguration/Rules/*: /properties/LifecycleConfiguration/Rules/*/AbortIncompleteMultipartUpload, /properties/LifecycleConfiguration/Rules/*/ExpirationDate, /properties/LifecycleConfiguration/Rules/*/ExpirationInDays",
+				"exactlyOneOf /properties/LifecycleConfiguration/Rules/*/Filter: /properties/LifecycleConfiguration/Rules/*/Filter/Prefix, /properties/LifecycleConfiguration/Rules/*/Filter/Tag, /properties/LifecycleConfiguration/Rules/*/Filter/AndOperator",
+			},
+		},
+		{
+			TestDescription:    "nested anyOf and oneOf expanded",
+			ResourceSchemaPath: "AWS_S3Outposts_Bucket.json",
+			Expand:             true,
+			Expected: []string{
+				"atLeastOneOf /properties/LifecycleConfiguration/Rules/*: /properties/LifecycleConfiguration/Rules/*/AbortIncompleteMultipartUpload, /properties/LifecycleConfiguration/Rules/*/ExpirationDate, /properties/LifecycleConfiguration/Rules/*/ExpirationInDays",
+				"exactlyOneOf /properties/LifecycleConfiguration/Rules/*/Filter: /properties/LifecycleConfiguration/Rules/*/Filter/Prefix, /properties/LifecycleConfiguration/Rules/*/Filter/Tag, /properties/LifecycleConfiguration/Rules/*/Filter/AndOperator",
+			},
+		},
+		{
+			TestDescription: "unwrapped oneOf",
+			Schema:          `{"typeName": "Initech::TPS::Report", "definitions": {"Transformation": {"type": "object", "oneOf": [{"properties": {"AwsLambda": {"type": "string"}}, "required": ["AwsLambda"]}, {"properties": {"Script": {"type": "string"}}, "required": ["Script"]}]}}, "properties": {"ContentTransformation": {"$ref": "#/definitions/Transformation"}}}`,
+			Expand:          true,
+			Expected: []string{
+				"exactlyOneOf /properties/ContentTransformation: /properties/ContentTransformation/AwsLambda, /properties/ContentTransformation/Script",
+			},
+		},
+		{
+			TestDescription: "optional oneOf",
+			Schema:          `{"typeName": "Initech::TPS::Report", "properties": {"Source": {"type": "object", "properties": {"Arn": {"type": "string"}, "Name": {"type": "string"}}, "oneOf": [{"properties": {"Arn": {"type": "string"}}}, {"properties": {"Name": {"type": "string"}}}]}}}`,
+			Expected: []string{
+				"conflictsWith /properties/Source: /properties/Source/Arn, /properties/Source/Name",
+			},
+		},
+		{
+			TestDescription: "unconstrained anyOf",
+			Schema:          `{"typeName": "Initech::TPS::Report", "properties": {"A": {"type": "string"}, "B": {"type": "string"}}, "anyOf": [{"required": ["A", "B"]}, {}]}`,
+		},
+		{
+			TestDescription: "unconstrained oneOf",
+			Schema:          `{"typeName": "Initech::TPS::Report", "properties": {"A": {"type": "string"}, "B": {"type": "string"}, "C": {"type": "string"}}, "oneOf": [{"required": ["A", "B"]}, {"properties": {"C": {"type": "string"}}}]}`,
+			Expected: []string{
+				"conflictsWith /properties: /properties/A, /properties/C",
+			},
+		},
+		{
+			TestDescription: "dependencies",
+			Schema:          `{"typeName": "Initech::TPS::Report", "properties": {"Memos": {"type": "array", "items": {"type": "object", "properties": {"Body": {"type": "string"}, "Heading": {"type": "string"}}, "dependencies": {"Body": ["Heading"]}}}}}`,
+			Expected: []string{
+				"requiredWith /properties/Memos/*: /properties/Memos/*/Body, /properties/Memos/*/Heading",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			var resource *cfschema.Resource
+
+			if testCase.ResourceSchemaPath != "" {
+				resource = loadAndValidateResourceSchema(t, "provider.definition.schema.v1.json", testCase.ResourceSchemaPath)
+			} else {
+				resource = &cfschema.Resource{}
+
+				if err := json.Unmarshal([]byte(testCase.Schema), resource); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+
+			if testCase.Expand {
+				if err := resource.Expand(); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+
+			groups := resource.AttributeGroups()
+
+			if actual, expected := len(groups), len(testCase.Expected); actual != expected {
+				t.Fatalf("expected %d groups, got %d:\n%s", expected, actual, groups)
+			}
+
+			for i, group := range groups {
+				if actual, expected := group.String(), testCase.Expected[i]; actual != expected {
+					t.Errorf("expected group (%s), got: %s", expected, actual)
+				}
+			}
+		})
+	}
+}
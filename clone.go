@@ -0,0 +1,380 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"encoding/json"
+)
+
+// cloneResource returns a deep copy of the Resource.
+// The copy shares any ReferenceLoader and documents already loaded by it.
+func cloneResource(r *Resource) *Resource {
+	if r == nil {
+		return nil
+	}
+
+	c := *r
+
+	c.AdditionalIdentifiers = nil
+	c.AdditionalProperties = clonePointer(r.AdditionalProperties)
+	c.AllOf = clonePropertySubschemas(r.AllOf, nil)
+	c.AnyOf = clonePropertySubschemas(r.AnyOf, nil)
+	c.ConditionalCreateOnlyProperties = cloneSlice(r.ConditionalCreateOnlyProperties)
+	c.CreateOnlyProperties = cloneSlice(r.CreateOnlyProperties)
+	c.Definitions = cloneProperties(r.Definitions, nil)
+	c.DefinitionsOrder = cloneSlice(r.DefinitionsOrder)
+	c.DeprecatedProperties = cloneSlice(r.DeprecatedProperties)
+	c.Description = clonePointer(r.Description)
+	c.DocumentationURL = clonePointer(r.DocumentationURL)
+	c.EmptyValues = cloneExtensions(r.EmptyValues)
+	c.Extensions = cloneExtensions(r.Extensions)
+	c.Handlers = nil
+	c.NonPublicDefinitions = cloneSlice(r.NonPublicDefinitions)
+	c.NonPublicProperties = cloneSlice(r.NonPublicProperties)
+	c.OneOf = clonePropertySubschemas(r.OneOf, nil)
+	c.PrimaryIdentifier = cloneSlice(r.PrimaryIdentifier)
+	c.Properties = cloneProperties(r.Properties, nil)
+	c.PropertiesOrder = cloneSlice(r.PropertiesOrder)
+	c.PropertyTransform = cloneMap(r.PropertyTransform)
+	c.ReadOnlyProperties = cloneSlice(r.ReadOnlyProperties)
+	c.Remote = nil
+	c.ReplacementStrategy = clonePointer(r.ReplacementStrategy)
+	c.Required = cloneSlice(r.Required)
+	c.ResourceLink = nil
+	c.Schema = clonePointer(r.Schema)
+	c.SourceURL = clonePointer(r.SourceURL)
+	c.Taggable = clonePointer(r.Taggable)
+	c.Tagging = nil
+	c.Title = clonePointer(r.Title)
+	c.TypeConfiguration = nil
+	c.TypeName = clonePointer(r.TypeName)
+	c.WriteOnlyProperties = cloneSlice(r.WriteOnlyProperties)
+
+	c.expandState = nil
+
+	if r.AdditionalIdentifiers != nil {
+		c.AdditionalIdentifiers = make([]PropertyJsonPointers, len(r.AdditionalIdentifiers))
+
+		for i, additionalIdentifier := range r.AdditionalIdentifiers {
+			c.AdditionalIdentifiers[i] = cloneSlice(additionalIdentifier)
+		}
+	}
+
+	if r.Handlers != nil {
+		c.Handlers = make(map[string]*Handler, len(r.Handlers))
+
+		for handlerType, handler := range r.Handlers {
+			c.Handlers[handlerType] = cloneHandler(handler)
+		}
+	}
+
+	if r.Remote != nil {
+		c.Remote = make(map[string]*Remote, len(r.Remote))
+
+		for remoteName, remote := range r.Remote {
+			if remote == nil {
+				c.Remote[remoteName] = nil
+				continue
+			}
+
+			c.Remote[remoteName] = &Remote{
+				Comment:     clonePointer(remote.Comment),
+				Definitions: cloneProperties(remote.Definitions, nil),
+				Properties:  cloneProperties(remote.Properties, nil),
+			}
+		}
+	}
+
+	if r.ResourceLink != nil {
+		c.ResourceLink = &ResourceLink{
+			Comment:     clonePointer(r.ResourceLink.Comment),
+			Mappings:    cloneMap(r.ResourceLink.Mappings),
+			TemplateURI: clonePointer(r.ResourceLink.TemplateURI),
+		}
+	}
+
+	if r.Tagging != nil {
+		c.Tagging = &Tagging{
+			Taggable:                 clonePointer(r.Tagging.Taggable),
+			TagOnCreate:              clonePointer(r.Tagging.TagOnCreate),
+			TagUpdatable:             clonePointer(r.Tagging.TagUpdatable),
+			CloudFormationSystemTags: clonePointer(r.Tagging.CloudFormationSystemTags),
+			TagProperty:              clonePointer(r.Tagging.TagProperty),
+			Permissions:              cloneSlice(r.Tagging.Permissions),
+			EmptyValues:              cloneExtensions(r.Tagging.EmptyValues),
+			Extensions:               cloneExtensions(r.Tagging.Extensions),
+		}
+	}
+
+	if r.TypeConfiguration != nil {
+		c.TypeConfiguration = &TypeConfiguration{
+			AdditionalProperties: clonePointer(r.TypeConfiguration.AdditionalProperties),
+			AllOf:                clonePropertySubschemas(r.TypeConfiguration.AllOf, nil),
+			AnyOf:                clonePropertySubschemas(r.TypeConfiguration.AnyOf, nil),
+			DeprecatedProperties: cloneSlice(r.TypeConfiguration.DeprecatedProperties),
+			Description:          clonePointer(r.TypeConfiguration.Description),
+			OneOf:                clonePropertySubschemas(r.TypeConfiguration.OneOf, nil),
+			Properties:           cloneProperties(r.TypeConfiguration.Properties, nil),
+			Required:             cloneSlice(r.TypeConfiguration.Required),
+		}
+	}
+
+	return &c
+}
+
+// cloneHandler returns a deep copy of the Handler.
+func cloneHandler(h *Handler) *Handler {
+	if h == nil {
+		return nil
+	}
+
+	c := &Handler{
+		EmptyValues:      cloneExtensions(h.EmptyValues),
+		Extensions:       cloneExtensions(h.Extensions),
+		Permissions:      cloneSlice(h.Permissions),
+		SourcePosition:   clonePointer(h.SourcePosition),
+		TimeoutInMinutes: h.TimeoutInMinutes,
+	}
+
+	if h.HandlerSchema != nil {
+		c.HandlerSchema = &HandlerSchema{
+			AllOf:      clonePropertySubschemas(h.HandlerSchema.AllOf, nil),
+			AnyOf:      clonePropertySubschemas(h.HandlerSchema.AnyOf, nil),
+			OneOf:      clonePropertySubschemas(h.HandlerSchema.OneOf, nil),
+			Properties: cloneProperties(h.HandlerSchema.Properties, nil),
+			Required:   cloneSlice(h.HandlerSchema.Required),
+		}
+	}
+
+	return c
+}
+
+// cloneProperty returns a deep copy of the Property.
+// If mapRef is not nil, every Reference in the copy is replaced with the result of mapRef.
+func cloneProperty(p *Property, mapRef func(Reference) Reference) *Property {
+	if p == nil {
+		return nil
+	}
+
+	c := *p
+
+	c.AdditionalProperties = clonePointer(p.AdditionalProperties)
+	c.AllOf = clonePropertySubschemas(p.AllOf, mapRef)
+	c.AnyOf = clonePropertySubschemas(p.AnyOf, mapRef)
+	c.ArrayType = clonePointer(p.ArrayType)
+	c.Comment = clonePointer(p.Comment)
+	c.Const = cloneValue(p.Const)
+	c.Contains = cloneProperty(p.Contains, mapRef)
+	c.Default = cloneValue(p.Default)
+	c.Description = clonePointer(p.Description)
+	c.EmptyValues = cloneExtensions(p.EmptyValues)
+	c.Enum = cloneValues(p.Enum)
+	c.Examples = cloneValues(p.Examples)
+	c.ExclusiveMaximum = clonePointer(p.ExclusiveMaximum)
+	c.ExclusiveMinimum = clonePointer(p.ExclusiveMinimum)
+	c.Extensions = cloneExtensions(p.Extensions)
+	c.Format = clonePointer(p.Format)
+	c.InsertionOrder = clonePointer(p.InsertionOrder)
+	c.Items = cloneProperty(p.Items, mapRef)
+	c.Maximum = clonePointer(p.Maximum)
+	c.MaxItems = clonePointer(p.MaxItems)
+	c.MaxLength = clonePointer(p.MaxLength)
+	c.MaxProperties = clonePointer(p.MaxProperties)
+	c.Minimum = clonePointer(p.Minimum)
+	c.MinItems = clonePointer(p.MinItems)
+	c.MinLength = clonePointer(p.MinLength)
+	c.MinProperties = clonePointer(p.MinProperties)
+	c.MultipleOf = clonePointer(p.MultipleOf)
+	c.Not = cloneProperty(p.Not, mapRef)
+	c.OneOf = clonePropertySubschemas(p.OneOf, mapRef)
+	c.Pattern = clonePointer(p.Pattern)
+	c.PatternProperties = cloneProperties(p.PatternProperties, mapRef)
+	c.PatternPropertiesOrder = cloneSlice(p.PatternPropertiesOrder)
+	c.Properties = cloneProperties(p.Properties, mapRef)
+	c.PropertiesOrder = cloneSlice(p.PropertiesOrder)
+	c.PropertyNames = cloneProperty(p.PropertyNames, mapRef)
+	c.RecursiveRef = clonePointer(p.RecursiveRef)
+	c.Ref = clonePointer(p.Ref)
+	c.Required = cloneSlice(p.Required)
+	c.ResolvedRefs = cloneSlice(p.ResolvedRefs)
+	c.SourcePosition = clonePointer(p.SourcePosition)
+	c.Title = clonePointer(p.Title)
+	c.Type = clonePointer(p.Type)
+	c.UniqueItems = clonePointer(p.UniqueItems)
+	c.UnwrappedOneOf = clonePropertySubschemas(p.UnwrappedOneOf, mapRef)
+
+	if p.Dependencies != nil {
+		c.Dependencies = make(map[string]*PropertyDependency, len(p.Dependencies))
+
+		for name, dependency := range p.Dependencies {
+			if dependency == nil {
+				c.Dependencies[name] = nil
+				continue
+			}
+
+			c.Dependencies[name] = &PropertyDependency{
+				Properties: cloneSlice(dependency.Properties),
+				Schema:     cloneProperty(dependency.Schema, mapRef),
+			}
+		}
+	}
+
+	if p.RelationshipRef != nil {
+		c.RelationshipRef = &PropertyRelationshipRef{
+			PropertyPath: clonePointer(p.RelationshipRef.PropertyPath),
+			TypeName:     clonePointer(p.RelationshipRef.TypeName),
+		}
+	}
+
+	if c.RecursiveRef != nil && mapRef != nil {
+		ref := mapRef(*c.RecursiveRef)
+		c.RecursiveRef = &ref
+	}
+
+	if c.Ref != nil && mapRef != nil {
+		ref := mapRef(*c.Ref)
+		c.Ref = &ref
+	}
+
+	if mapRef != nil {
+		for i, ref := range c.ResolvedRefs {
+			c.ResolvedRefs[i] = mapRef(ref)
+		}
+	}
+
+	return &c
+}
+
+// cloneProperties returns a deep copy of a name-to-property map.
+func cloneProperties(properties map[string]*Property, mapRef func(Reference) Reference) map[string]*Property {
+	if properties == nil {
+		return nil
+	}
+
+	c := make(map[string]*Property, len(properties))
+
+	for name, property := range properties {
+		c[name] = cloneProperty(property, mapRef)
+	}
+
+	return c
+}
+
+// clonePropertySubschema returns a deep copy of the PropertySubschema.
+func clonePropertySubschema(s *PropertySubschema, mapRef func(Reference) Reference) *PropertySubschema {
+	if s == nil {
+		return nil
+	}
+
+	return &PropertySubschema{
+		AllOf:           clonePropertySubschemas(s.AllOf, mapRef),
+		AnyOf:           clonePropertySubschemas(s.AnyOf, mapRef),
+		EmptyValues:     cloneExtensions(s.EmptyValues),
+		Extensions:      cloneExtensions(s.Extensions),
+		OneOf:           clonePropertySubschemas(s.OneOf, mapRef),
+		Properties:      cloneProperties(s.Properties, mapRef),
+		PropertiesOrder: cloneSlice(s.PropertiesOrder),
+		Required:        cloneSlice(s.Required),
+	}
+}
+
+// clonePropertySubschemas returns a deep copy of a list of PropertySubschema.
+func clonePropertySubschemas(subschemas []*PropertySubschema, mapRef func(Reference) Reference) []*PropertySubschema {
+	if subschemas == nil {
+		return nil
+	}
+
+	c := make([]*PropertySubschema, len(subschemas))
+
+	for i, subschema := range subschemas {
+		c[i] = clonePropertySubschema(subschema, mapRef)
+	}
+
+	return c
+}
+
+// cloneExtensions returns a deep copy of Extensions.
+func cloneExtensions(e Extensions) Extensions {
+	if e == nil {
+		return nil
+	}
+
+	c := make(Extensions, len(e))
+
+	for key, value := range e {
+		c[key] = cloneSlice(value)
+	}
+
+	return c
+}
+
+// clonePointer returns a copy of the pointed-to value.
+func clonePointer[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+
+	c := *v
+
+	return &c
+}
+
+// cloneMap returns a copy of the map.
+func cloneMap[M ~map[K]V, K comparable, V any](m M) M {
+	if m == nil {
+		return nil
+	}
+
+	c := make(M, len(m))
+
+	for key, value := range m {
+		c[key] = value
+	}
+
+	return c
+}
+
+// cloneSlice returns a copy of the slice.
+func cloneSlice[S ~[]E, E any](s S) S {
+	if s == nil {
+		return nil
+	}
+
+	return append(S(make([]E, 0, len(s))), s...)
+}
+
+// cloneValue returns a deep copy of an arbitrary JSON value.
+func cloneValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(v))
+
+		for key, value := range v {
+			c[key] = cloneValue(value)
+		}
+
+		return c
+	case []interface{}:
+		return cloneValues(v)
+	case json.RawMessage:
+		return cloneSlice(v)
+	default:
+		return v
+	}
+}
+
+// cloneValues returns a deep copy of a list of arbitrary JSON values.
+func cloneValues(values []interface{}) []interface{} {
+	if values == nil {
+		return nil
+	}
+
+	c := make([]interface{}, len(values))
+
+	for i, value := range values {
+		c[i] = cloneValue(value)
+	}
+
+	return c
+}
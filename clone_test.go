@@ -0,0 +1,105 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestResourceClone(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "AWS_*.json"))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, path := range paths {
+		path := path
+
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			b, err := os.ReadFile(path)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var resource cfschema.Resource
+
+			if err := json.Unmarshal(b, &resource); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			expected, err := json.Marshal(resource)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			clone := resource.Clone()
+
+			if !reflect.DeepEqual(clone, &resource) {
+				t.Fatal("expected clone to equal original")
+			}
+
+			// Expand modifies the clone in place.
+			if err := clone.Expand(); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			actual, err := json.Marshal(resource)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if string(actual) != string(expected) {
+				t.Errorf("expected original to be unmodified, got: %s", actual)
+			}
+		})
+	}
+}
+
+func TestPropertyClone(t *testing.T) {
+	var property cfschema.Property
+
+	document := `{"dependencies":{"Tags":["Name"]},"enum":[{"a":[1]}],"oneOf":[{"required":["Tags"]}],"properties":{"Tags":{"items":{"properties":{"Key":{"type":"string"}},"type":"object"},"type":"array"}},"type":"object","x-extension":[1]}`
+
+	if err := json.Unmarshal([]byte(document), &property); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	clone := property.Clone()
+
+	if !reflect.DeepEqual(clone, &property) {
+		t.Fatal("expected clone to equal original")
+	}
+
+	typ := cfschema.Type(cfschema.PropertyTypeInteger)
+	clone.Properties["Tags"].Items.Properties["Key"].Type = &typ
+	clone.Properties["Tags"].Items.Properties["Value"] = &cfschema.Property{}
+	clone.Dependencies["Tags"].Properties[0] = "Changed"
+	clone.Enum[0].(map[string]interface{})["a"].([]interface{})[0] = 2
+	clone.OneOf[0].Required[0] = "Changed"
+	clone.Extensions["x-extension"][1] = '2'
+
+	actual, err := json.Marshal(property)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := document; string(actual) != expected {
+		t.Errorf("expected original (%s), got: %s", expected, actual)
+	}
+
+	if clone.OneOf[0].Clone().Required[0] != "Changed" {
+		t.Error("expected subschema clone to equal clone")
+	}
+}
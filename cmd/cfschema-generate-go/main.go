@@ -0,0 +1,81 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// cfschema-generate-go generates Go types from a CloudFormation resource schema.
+//
+// Usage:
+//
+//	cfschema-generate-go [-package name] [-type name] [-o file] schema.json
+//
+// The generated source is written to standard output unless an output file is specified.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil && !errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintf(os.Stderr, "cfschema-generate-go: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("cfschema-generate-go", flag.ContinueOnError)
+	output := flags.String("o", "", "output file (default standard output)")
+	packageName := flags.String("package", "main", "package name of the generated source")
+	typeName := flags.String("type", "", "name of the generated resource type (default last segment of the resource typeName)")
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: cfschema-generate-go [-package name] [-type name] [-o file] schema.json\n\n")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+
+		return fmt.Errorf("expected 1 resource schema path, got %d", flags.NArg())
+	}
+
+	resourceSchema, err := cfschema.NewResourceJsonSchemaPath(flags.Arg(0))
+
+	if err != nil {
+		return err
+	}
+
+	resource, err := resourceSchema.Resource()
+
+	if err != nil {
+		return err
+	}
+
+	var opts []cfschema.GoGeneratorOption
+
+	if *typeName != "" {
+		opts = append(opts, cfschema.WithGoTypeName(*typeName))
+	}
+
+	src, err := cfschema.GenerateGo(resource, *packageName, opts...)
+
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		_, err = os.Stdout.Write(src)
+
+		return err
+	}
+
+	return os.WriteFile(*output, src, 0644)
+}
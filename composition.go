@@ -0,0 +1,339 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// CompositionConflict is a keyword defined differently by a property and the allOf, anyOf or oneOf branches merged into it.
+//
+// Location is the RFC 6901 JSON Pointer to the conflicting property in the flattened schema,
+// e.g. /properties/Filter/items/properties/Key, or empty for the flattened property itself.
+type CompositionConflict struct {
+	Keyword  string
+	Location string
+	Message  string
+}
+
+// String returns a string representation of CompositionConflict.
+func (c *CompositionConflict) String() string {
+	if c == nil {
+		return ""
+	}
+
+	if c.Location == "" {
+		return c.Message
+	}
+
+	return fmt.Sprintf("%s: %s", c.Location, c.Message)
+}
+
+// CompositionConflicts is a list of CompositionConflict.
+type CompositionConflicts []*CompositionConflict
+
+// String returns a string representation of CompositionConflicts, one per line.
+func (cs CompositionConflicts) String() string {
+	var lines []string
+
+	for _, c := range cs {
+		lines = append(lines, c.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Flatten returns a copy of the Property with all allOf, anyOf and oneOf compositions, at any depth, merged into it.
+//
+// The properties, required properties and other keywords of allOf branches are merged into the effective property.
+// Where the same keyword is defined differently, the first definition is kept and a CompositionConflict returned,
+// with a Location relative to this Property. Annotations such as descriptions never conflict.
+//
+// As only some anyOf and oneOf branches apply, their properties are unioned and only properties required by every
+// branch become required. A property defined by several branches, or also by the Property itself, is merged as for
+// allOf, so differing definitions are conflicts. The other keywords of anyOf and oneOf branches are not merged.
+//
+// References are not followed, so Expand should be called on the Resource first.
+func (p *Property) Flatten() (*Property, CompositionConflicts) {
+	if p == nil {
+		return nil, nil
+	}
+
+	f := &compositionFlattener{}
+	c := cloneProperty(p, nil)
+
+	f.flattenProperty(c, nil)
+
+	return c, f.conflicts
+}
+
+// Flatten returns a copy of the Resource with all allOf, anyOf and oneOf compositions merged as described by Property.Flatten.
+// This includes the resource-level compositions, which are merged into the top-level Properties and Required.
+// CompositionConflict Locations are relative to the resource schema, e.g. /definitions/Tag/properties/Key.
+func (r *Resource) Flatten() (*Resource, CompositionConflicts) {
+	if r == nil {
+		return nil, nil
+	}
+
+	f := &compositionFlattener{}
+	c := r.Clone()
+
+	for _, name := range sortedPropertyNames(c.Definitions) {
+		f.flattenProperty(c.Definitions[name], []string{ReferenceTypeDefinitions, name})
+	}
+
+	root := &Property{
+		AllOf:           c.AllOf,
+		AnyOf:           c.AnyOf,
+		OneOf:           c.OneOf,
+		Properties:      c.Properties,
+		PropertiesOrder: c.PropertiesOrder,
+		Required:        c.Required,
+	}
+
+	f.flattenProperty(root, nil)
+
+	c.AllOf = nil
+	c.AnyOf = nil
+	c.OneOf = nil
+	c.Properties = root.Properties
+	c.PropertiesOrder = root.PropertiesOrder
+	c.Required = root.Required
+
+	return c, f.conflicts
+}
+
+// compositionFlattener accumulates conflicts while flattening compositions.
+type compositionFlattener struct {
+	conflicts CompositionConflicts
+}
+
+// flattenProperty merges the compositions of the Property, and of all its nested properties, in place.
+func (f *compositionFlattener) flattenProperty(p *Property, path []string) {
+	if p == nil {
+		return
+	}
+
+	for _, name := range sortedPropertyNames(p.Properties) {
+		f.flattenProperty(p.Properties[name], appendPath(path, "properties", name))
+	}
+
+	for _, name := range sortedPropertyNames(p.PatternProperties) {
+		f.flattenProperty(p.PatternProperties[name], appendPath(path, "patternProperties", name))
+	}
+
+	f.flattenProperty(p.Items, appendPath(path, "items"))
+
+	allOf, anyOf, oneOf := p.AllOf, p.AnyOf, p.OneOf
+
+	p.AllOf = nil
+	p.AnyOf = nil
+	p.OneOf = nil
+
+	for _, subschema := range allOf {
+		f.mergeProperty(p, f.flattenSubschema(subschema, path), path)
+	}
+
+	for _, subschemas := range [][]*PropertySubschema{anyOf, oneOf} {
+		var required []string
+
+		for i, subschema := range subschemas {
+			branch := f.flattenSubschema(subschema, path)
+
+			if i == 0 {
+				required = branch.Required
+			} else {
+				required = intersectStrings(required, branch.Required)
+			}
+
+			// Only one branch need apply, so only its properties are merged and not its other keywords.
+			if len(branch.Properties) > 0 {
+				p.Properties = f.mergeProperties(p.Properties, branch.Properties, appendPath(path, "properties"))
+			}
+
+			p.PropertiesOrder = unionStrings(p.PropertiesOrder, branch.PropertiesOrder)
+		}
+
+		p.Required = unionStrings(p.Required, required)
+	}
+
+	if p.Type == nil && (len(p.Properties) > 0 || len(p.PatternProperties) > 0) {
+		typ := Type(PropertyTypeObject)
+		p.Type = &typ
+	}
+}
+
+// flattenSubschema returns the effective Property of a composition branch.
+// Keywords other than properties and required, e.g. type, are captured in the PropertySubschema Extensions.
+func (f *compositionFlattener) flattenSubschema(s *PropertySubschema, path []string) *Property {
+	p := &Property{}
+
+	if s == nil {
+		return p
+	}
+
+	if len(s.Extensions) > 0 {
+		if b, err := json.Marshal(s.Extensions); err == nil {
+			_ = json.Unmarshal(b, p)
+		}
+	}
+
+	p.AllOf = s.AllOf
+	p.AnyOf = s.AnyOf
+	p.OneOf = s.OneOf
+	p.Properties = s.Properties
+	p.PropertiesOrder = s.PropertiesOrder
+	p.Required = s.Required
+
+	f.flattenProperty(p, path)
+
+	return p
+}
+
+// mergeProperty merges the flattened src Property into the flattened dst Property, recording any conflicts.
+func (f *compositionFlattener) mergeProperty(dst, src *Property, path []string) {
+	dstValue := reflect.ValueOf(dst).Elem()
+	srcValue := reflect.ValueOf(src).Elem()
+
+	for i := 0; i < dstValue.NumField(); i++ {
+		field := dstValue.Type().Field(i)
+		dstField := dstValue.Field(i)
+		srcField := srcValue.Field(i)
+
+		if srcField.IsZero() {
+			continue
+		}
+
+		switch field.Name {
+		case "Items":
+			if dst.Items == nil {
+				dst.Items = src.Items
+			} else {
+				f.mergeProperty(dst.Items, src.Items, appendPath(path, "items"))
+			}
+		case "PatternProperties":
+			dst.PatternProperties = f.mergeProperties(dst.PatternProperties, src.PatternProperties, appendPath(path, "patternProperties"))
+		case "Properties":
+			dst.Properties = f.mergeProperties(dst.Properties, src.Properties, appendPath(path, "properties"))
+		case "PatternPropertiesOrder":
+			dst.PatternPropertiesOrder = unionStrings(dst.PatternPropertiesOrder, src.PatternPropertiesOrder)
+		case "PropertiesOrder":
+			dst.PropertiesOrder = unionStrings(dst.PropertiesOrder, src.PropertiesOrder)
+		case "Required":
+			dst.Required = unionStrings(dst.Required, src.Required)
+		case "Extensions":
+			for key, value := range src.Extensions {
+				if _, ok := dst.Extensions[key]; !ok {
+					if dst.Extensions == nil {
+						dst.Extensions = make(Extensions)
+					}
+
+					dst.Extensions[key] = value
+				}
+			}
+		case "Comment", "Description", "EmptyValues", "Examples", "ResolvedRefs", "SourcePosition", "Title", "UnwrappedOneOf":
+			if dstField.IsZero() {
+				dstField.Set(srcField)
+			}
+		default:
+			if dstField.IsZero() {
+				dstField.Set(srcField)
+				continue
+			}
+
+			if reflect.DeepEqual(dstField.Interface(), srcField.Interface()) {
+				continue
+			}
+
+			keyword := strings.Split(field.Tag.Get("json"), ",")[0]
+
+			if keyword == "" || keyword == "-" {
+				keyword = field.Name
+			}
+
+			f.conflicts = append(f.conflicts, &CompositionConflict{
+				Keyword:  keyword,
+				Location: joinJsonPointer(path),
+				Message:  fmt.Sprintf("conflicting %s definitions: %s and %s", keyword, compositionValue(dstField), compositionValue(srcField)),
+			})
+		}
+	}
+}
+
+// mergeProperties merges the src name-to-property map at path into the dst map, returning the merged map.
+func (f *compositionFlattener) mergeProperties(dst, src map[string]*Property, path []string) map[string]*Property {
+	if dst == nil {
+		dst = make(map[string]*Property, len(src))
+	}
+
+	for _, name := range sortedPropertyNames(src) {
+		if existing, ok := dst[name]; ok && existing != nil && src[name] != nil {
+			f.mergeProperty(existing, src[name], appendPath(path, name))
+			continue
+		}
+
+		if dst[name] == nil {
+			dst[name] = src[name]
+		}
+	}
+
+	return dst
+}
+
+// compositionValue returns the JSON representation of a Property field value for messages.
+func compositionValue(v reflect.Value) string {
+	b, err := json.Marshal(v.Interface())
+
+	if err != nil {
+		return fmt.Sprintf("%v", v.Interface())
+	}
+
+	return string(b)
+}
+
+// appendPath returns a copy of the path with the tokens appended.
+func appendPath(path []string, tokens ...string) []string {
+	return append(path[:len(path):len(path)], tokens...)
+}
+
+// intersectStrings returns the values of a, in order, that are also in b.
+func intersectStrings(a, b []string) []string {
+	var values []string
+
+	for _, v := range a {
+		for _, w := range b {
+			if v == w {
+				values = append(values, v)
+				break
+			}
+		}
+	}
+
+	return values
+}
+
+// unionStrings returns the values of a followed by any values of b not in a.
+func unionStrings(a, b []string) []string {
+	values := cloneSlice(a)
+
+	for _, v := range b {
+		found := false
+
+		for _, w := range values {
+			if v == w {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,191 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestPropertyFlatten(t *testing.T) {
+	testCases := []struct {
+		TestDescription   string
+		Property          string
+		Expected          string
+		ExpectedConflicts []string
+	}{
+		{
+			TestDescription: "no composition",
+			Property:        `{"type": "object", "properties": {"Name": {"type": "string"}}}`,
+			Expected:        `{"properties": {"Name": {"type": "string"}}, "type": "object"}`,
+		},
+		{
+			TestDescription: "allOf",
+			Property:        `{"type": "object", "properties": {"Name": {"type": "string"}}, "allOf": [{"properties": {"Value": {"type": "integer"}}, "required": ["Value"]}, {"required": ["Name"]}]}`,
+			Expected:        `{"properties": {"Name": {"type": "string"}, "Value": {"type": "integer"}}, "required": ["Value", "Name"], "type": "object"}`,
+		},
+		{
+			TestDescription: "oneOf",
+			Property:        `{"oneOf": [{"properties": {"Arn": {"type": "string"}, "Role": {"type": "string"}}, "required": ["Arn", "Role"]}, {"properties": {"Name": {"type": "string"}, "Role": {"type": "string"}}, "required": ["Name", "Role"]}]}`,
+			Expected:        `{"properties": {"Arn": {"type": "string"}, "Name": {"type": "string"}, "Role": {"type": "string"}}, "required": ["Role"], "type": "object"}`,
+		},
+		{
+			TestDescription: "anyOf",
+			Property:        `{"type": "object", "properties": {"Prefix": {"type": "string"}, "Tag": {"type": "string"}}, "anyOf": [{"required": ["Prefix"]}, {"required": ["Tag"]}]}`,
+			Expected:        `{"properties": {"Prefix": {"type": "string"}, "Tag": {"type": "string"}}, "type": "object"}`,
+		},
+		{
+			TestDescription: "nested compositions",
+			Property:        `{"type": "object", "properties": {"Filter": {"type": "array", "items": {"allOf": [{"properties": {"Key": {"type": "string"}}}, {"oneOf": [{"properties": {"Value": {"type": "string"}}}]}]}}}}`,
+			Expected:        `{"properties": {"Filter": {"items": {"properties": {"Key": {"type": "string"}, "Value": {"type": "string"}}, "type": "object"}, "type": "array"}}, "type": "object"}`,
+		},
+		{
+			TestDescription: "subschema keywords",
+			Property:        `{"allOf": [{"type": "object", "additionalProperties": false, "properties": {"Name": {"type": "string"}}}]}`,
+			Expected:        `{"additionalProperties": false, "properties": {"Name": {"type": "string"}}, "type": "object"}`,
+		},
+		{
+			TestDescription: "annotations",
+			Property:        `{"properties": {"Name": {"type": "string", "description": "first"}}, "allOf": [{"properties": {"Name": {"type": "string", "description": "second", "maxLength": 64}}}]}`,
+			Expected:        `{"properties": {"Name": {"description": "first", "maxLength": 64, "type": "string"}}, "type": "object"}`,
+		},
+		{
+			TestDescription:   "oneOf property defined differently",
+			Property:          `{"properties": {"Name": {"type": "string"}}, "oneOf": [{"properties": {"Name": {"type": "integer"}}}]}`,
+			Expected:          `{"properties": {"Name": {"type": "string"}}, "type": "object"}`,
+			ExpectedConflicts: []string{`/properties/Name: conflicting type definitions: "string" and "integer"`},
+		},
+		{
+			TestDescription:   "anyOf branches defining a property differently",
+			Property:          `{"anyOf": [{"properties": {"Size": {"type": "integer", "description": "first"}}}, {"properties": {"Size": {"type": "integer", "description": "second"}}}, {"properties": {"Size": {"type": "string"}}}]}`,
+			Expected:          `{"properties": {"Size": {"description": "first", "type": "integer"}}, "type": "object"}`,
+			ExpectedConflicts: []string{`/properties/Size: conflicting type definitions: "integer" and "string"`},
+		},
+		{
+			TestDescription: "oneOf constraints",
+			Property:        `{"type": "object", "oneOf": [{"maxProperties": 1}, {"minProperties": 2}]}`,
+			Expected:        `{"type": "object"}`,
+		},
+		{
+			TestDescription: "anyOf types",
+			Property:        `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`,
+			Expected:        `{}`,
+		},
+		{
+			TestDescription:   "conflicting allOf type",
+			Property:          `{"properties": {"Name": {"type": "string"}}, "allOf": [{"properties": {"Name": {"type": "integer"}}}]}`,
+			Expected:          `{"properties": {"Name": {"type": "string"}}, "type": "object"}`,
+			ExpectedConflicts: []string{`/properties/Name: conflicting type definitions: "string" and "integer"`},
+		},
+		{
+			TestDescription:   "conflicting constraints",
+			Property:          `{"type": "array", "items": {"type": "string", "maxLength": 10}, "allOf": [{"type": "array", "maxItems": 5, "items": {"maxLength": 20}}, {"type": "string"}]}`,
+			Expected:          `{"items": {"maxLength": 10, "type": "string"}, "maxItems": 5, "type": "array"}`,
+			ExpectedConflicts: []string{`/items: conflicting maxLength definitions: 10 and 20`, `conflicting type definitions: "array" and "string"`},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			var property cfschema.Property
+
+			if err := json.Unmarshal([]byte(testCase.Property), &property); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			original, err := json.Marshal(property)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			flattened, conflicts := property.Flatten()
+
+			b, err := json.Marshal(flattened)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if actual, expected := string(b), compactJSON(t, testCase.Expected); actual != expected {
+				t.Errorf("expected (%s), got: %s", expected, actual)
+			}
+
+			if actual, expected := len(conflicts), len(testCase.ExpectedConflicts); actual != expected {
+				t.Fatalf("expected %d conflicts, got %d: %s", expected, actual, conflicts)
+			}
+
+			for i, conflict := range conflicts {
+				if actual, expected := conflict.String(), testCase.ExpectedConflicts[i]; actual != expected {
+					t.Errorf("expected conflict (%s), got: %s", expected, actual)
+				}
+			}
+
+			b, err = json.Marshal(property)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if string(b) != string(original) {
+				t.Errorf("expected original to be unmodified, got: %s", b)
+			}
+		})
+	}
+}
+
+func TestResourceFlatten(t *testing.T) {
+	testCases := []struct {
+		TestDescription    string
+		ResourceSchemaPath string
+		ExpectedRequired   []string
+	}{
+		{
+			TestDescription:    "allOf",
+			ResourceSchemaPath: "AWS_GameLift_Fleet.json",
+			ExpectedRequired:   []string{"EC2InstanceType", "Name"},
+		},
+		{
+			TestDescription:    "anyOf",
+			ResourceSchemaPath: "AWS_CloudWatch_MetricStream.json",
+			ExpectedRequired:   []string{"FirehoseArn", "RoleArn", "OutputFormat"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			resource := loadAndValidateResourceSchema(t, "provider.definition.schema.v1.json", testCase.ResourceSchemaPath)
+
+			if err := resource.Expand(); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			flattened, conflicts := resource.Flatten()
+
+			if len(conflicts) > 0 {
+				t.Errorf("unexpected conflicts: %s", conflicts)
+			}
+
+			if flattened.AllOf != nil || flattened.AnyOf != nil || flattened.OneOf != nil {
+				t.Error("expected no resource compositions")
+			}
+
+			if resource.AllOf == nil && resource.AnyOf == nil {
+				t.Error("expected original resource compositions")
+			}
+
+			for _, name := range testCase.ExpectedRequired {
+				if !flattened.IsRequired(name) {
+					t.Errorf("expected %s to be required", name)
+				}
+			}
+		})
+	}
+}
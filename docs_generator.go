@@ -0,0 +1,497 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strings"
+	"unicode"
+)
+
+// GenerateMarkdown returns Markdown reference documentation for the Resource.
+//
+// Each property, at any depth, is documented in its own section, in document order and followed by the
+// sections of its nested properties. A section lists the type, constraints such as pattern, minLength,
+// maximum and enum, whether the property is required, read-only, create-only, write-only or deprecated,
+// and its description. The anchor of each section is derived from the PropertyJsonPointer of the property,
+// e.g. properties-Memo-Heading for /properties/Memo/Heading, with a numeric suffix, e.g. properties-Memo-Heading-1,
+// where that anchor is already taken. Markdown syntax in descriptions is escaped.
+//
+// The Resource is documented in expanded form, using Expanded, and is not modified.
+func GenerateMarkdown(resource *Resource) ([]byte, error) {
+	doc, err := newDocsResource(resource)
+
+	if err != nil {
+		return nil, fmt.Errorf("generating Markdown: %w", err)
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "# %s\n", doc.Title)
+
+	if doc.Description != "" {
+		fmt.Fprintf(&buf, "\n%s\n", docsEscape(doc.Description))
+	}
+
+	fmt.Fprintf(&buf, "\n## <a id=\"%s\"></a>Properties\n", ReferenceTypeProperties)
+
+	for _, attribute := range doc.Attributes {
+		fmt.Fprintf(&buf, "\n%s <a id=\"%s\"></a>%s\n", strings.Repeat("#", attribute.Level), attribute.Anchor, docsCode(attribute.Name))
+
+		if attribute.Description != "" {
+			fmt.Fprintf(&buf, "\n%s\n", docsEscape(attribute.Description))
+		}
+
+		buf.WriteString("\n")
+		fmt.Fprintf(&buf, "- Type: %s\n", attribute.Type)
+
+		for _, flag := range attribute.Flags {
+			fmt.Fprintf(&buf, "- %s\n", flag)
+		}
+
+		for _, constraint := range attribute.Constraints {
+			fmt.Fprintf(&buf, "- %s: %s\n", constraint.Name, docsCodeList(constraint.Values))
+		}
+
+		if attribute.Recursive != nil {
+			fmt.Fprintf(&buf, "- Recursive: see [%s](#%s)\n", docsCode(attribute.Recursive.Name), attribute.Recursive.Anchor)
+		}
+
+		if len(attribute.Attributes) > 0 {
+			var links []string
+
+			for _, nested := range attribute.Attributes {
+				links = append(links, fmt.Sprintf("[%s](#%s)", docsCode(nested.Key), nested.Anchor))
+			}
+
+			fmt.Fprintf(&buf, "- Attributes: %s\n", strings.Join(links, ", "))
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// GenerateHTML returns HTML reference documentation for the Resource, as a complete page with the
+// same sections and anchors as GenerateMarkdown.
+func GenerateHTML(resource *Resource) ([]byte, error) {
+	doc, err := newDocsResource(resource)
+
+	if err != nil {
+		return nil, fmt.Errorf("generating HTML: %w", err)
+	}
+
+	var buf bytes.Buffer
+
+	if err := docsHTMLTemplate.Execute(&buf, doc); err != nil {
+		return nil, fmt.Errorf("generating HTML: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var docsHTMLTemplate = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+</head>
+<body>
+<h1>{{ .Title }}</h1>
+{{- if .Description }}
+<p>{{ .Description }}</p>
+{{- end }}
+<h2 id="properties">Properties</h2>
+{{- range .Attributes }}
+<section>
+<h{{ .Level }} id="{{ .Anchor }}"><code>{{ .Name }}</code></h{{ .Level }}>
+{{- if .Description }}
+<p>{{ .Description }}</p>
+{{- end }}
+<ul>
+<li>Type: {{ .Type }}</li>
+{{- range .Flags }}
+<li>{{ . }}</li>
+{{- end }}
+{{- range .Constraints }}
+<li>{{ .Name }}: {{ range $i, $v := .Values }}{{ if $i }}, {{ end }}<code>{{ $v }}</code>{{ end }}</li>
+{{- end }}
+{{- with .Recursive }}
+<li>Recursive: see <a href="#{{ .Anchor }}"><code>{{ .Name }}</code></a></li>
+{{- end }}
+{{- if .Attributes }}
+<li>Attributes: {{ range $i, $a := .Attributes }}{{ if $i }}, {{ end }}<a href="#{{ $a.Anchor }}"><code>{{ $a.Key }}</code></a>{{ end }}</li>
+{{- end }}
+</ul>
+</section>
+{{- end }}
+</body>
+</html>
+`))
+
+// docsResource is the documentation of a Resource, rendered by GenerateMarkdown and GenerateHTML.
+type docsResource struct {
+	Attributes  []*docsAttribute
+	Description string
+	Title       string
+}
+
+// docsAttribute is the documentation of a property.
+type docsAttribute struct {
+	Anchor      string
+	Attributes  []*docsAttribute
+	Constraints []*docsConstraint
+	Description string
+	Flags       []string
+	Key         string
+	Level       int
+	Name        string
+	Recursive   *docsAttribute
+	Type        string
+}
+
+// docsConstraint is a named constraint on the values of a property.
+type docsConstraint struct {
+	Name   string
+	Values []string
+}
+
+// newDocsResource returns the documentation of the expanded Resource.
+func newDocsResource(resource *Resource) (*docsResource, error) {
+	if resource == nil {
+		return nil, fmt.Errorf("Resource is required")
+	}
+
+	expanded, err := resource.Expanded()
+
+	if err != nil {
+		return nil, err
+	}
+
+	b := &docsBuilder{
+		ancestors: make(map[Reference]*docsAttribute),
+		anchors:   map[string]struct{}{ReferenceTypeProperties: {}},
+		resource:  expanded,
+	}
+
+	doc := &docsResource{}
+
+	if expanded.TypeName != nil {
+		doc.Title = *expanded.TypeName
+	}
+
+	if expanded.Description != nil {
+		doc.Description = *expanded.Description
+	}
+
+	root := &docsAttribute{}
+
+	for _, name := range expanded.OrderedPropertyNames() {
+		b.attribute(root, expanded.Properties[name], name, nil, expanded.Required, &WalkProperty{})
+	}
+
+	doc.Attributes = b.attributes
+
+	return doc, nil
+}
+
+// docsBuilder accumulates the documentation of properties in section order.
+type docsBuilder struct {
+	ancestors  map[Reference]*docsAttribute
+	anchors    map[string]struct{}
+	attributes []*docsAttribute
+	resource   *Resource
+}
+
+// attribute documents the named property of the parent, and then its nested properties.
+func (b *docsBuilder) attribute(parent *docsAttribute, property *Property, name string, path, required []string, flags *WalkProperty) {
+	if property == nil {
+		return
+	}
+
+	r := b.resource
+	path = appendPath(path, name)
+	pointer := NewPropertyJsonPointer(path...)
+	metadata := &WalkProperty{
+		CreateOnly: flags.CreateOnly || walkPointersContain(r.CreateOnlyProperties, path),
+		Deprecated: flags.Deprecated || walkPointersContain(r.DeprecatedProperties, path),
+		ReadOnly:   flags.ReadOnly || walkPointersContain(r.ReadOnlyProperties, path),
+		WriteOnly:  flags.WriteOnly || walkPointersContain(r.WriteOnlyProperties, path),
+	}
+
+	attribute := &docsAttribute{
+		Anchor: b.anchor(pointer),
+		Key:    name,
+		Level:  min(len(path)+2, 6),
+		Name:   strings.Join(path, ReferenceSeparator),
+		Type:   b.typeOf(property),
+	}
+
+	if property.Description != nil {
+		attribute.Description = strings.TrimSpace(*property.Description)
+	}
+
+	for _, flag := range []struct {
+		name string
+		set  bool
+	}{
+		{"Required", len(intersectStrings([]string{name}, required)) > 0},
+		{"Read-only", metadata.ReadOnly},
+		{"Create-only", metadata.CreateOnly},
+		{"Write-only", metadata.WriteOnly},
+		{"Deprecated", metadata.Deprecated},
+	} {
+		if flag.set {
+			attribute.Flags = append(attribute.Flags, flag.name)
+		}
+	}
+
+	attribute.Constraints = docsConstraints(property, "")
+
+	// Array items share the pointer of their array.
+	element := property
+
+	for element.Items != nil {
+		element = element.Items
+		attribute.Constraints = append(attribute.Constraints, docsConstraints(element, "Item ")...)
+	}
+
+	parent.Attributes = append(parent.Attributes, attribute)
+	b.attributes = append(b.attributes, attribute)
+
+	if element.RecursiveRef != nil {
+		attribute.Recursive = b.ancestors[*element.RecursiveRef]
+
+		return
+	}
+
+	for _, p := range []*Property{property, element} {
+		if len(p.ResolvedRefs) > 0 {
+			if _, ok := b.ancestors[p.ResolvedRefs[0]]; !ok {
+				b.ancestors[p.ResolvedRefs[0]] = attribute
+				defer delete(b.ancestors, p.ResolvedRefs[0])
+			}
+		}
+	}
+
+	properties := subschemaProperties(element)
+
+	for _, name := range orderedSubschemaPropertyNames(element) {
+		b.attribute(attribute, properties[name], name, path, element.Required, metadata)
+	}
+}
+
+// typeOf returns a description of the type of a property, e.g. List of String.
+// Recursive properties have the type of the ancestor they refer to.
+func (b *docsBuilder) typeOf(property *Property) string {
+	if property == nil {
+		return "Any"
+	}
+
+	if property.RecursiveRef != nil {
+		if ancestor, ok := b.ancestors[*property.RecursiveRef]; ok {
+			return ancestor.Type
+		}
+	}
+
+	types := property.Type.Types()
+
+	if len(types) == 0 {
+		switch {
+		case len(subschemaProperties(property)) > 0 || len(property.PatternProperties) > 0:
+			types = []string{PropertyTypeObject}
+		case property.Items != nil:
+			types = []string{PropertyTypeArray}
+		default:
+			return "Any"
+		}
+	}
+
+	var descriptions []string
+
+	for _, typ := range types {
+		switch typ {
+		case "":
+			continue
+		case PropertyTypeArray:
+			if property.UniqueItems != nil && *property.UniqueItems {
+				descriptions = append(descriptions, "Set of "+b.typeOf(property.Items))
+			} else {
+				descriptions = append(descriptions, "List of "+b.typeOf(property.Items))
+			}
+		case PropertyTypeObject:
+			if len(subschemaProperties(property)) == 0 && len(property.PatternProperties) == 1 {
+				for _, pattern := range property.OrderedPatternPropertyNames() {
+					descriptions = append(descriptions, "Map of "+b.typeOf(property.PatternProperties[pattern]))
+				}
+			} else {
+				descriptions = append(descriptions, "Object")
+			}
+		default:
+			descriptions = append(descriptions, strings.ToUpper(typ[:1])+typ[1:])
+		}
+	}
+
+	return strings.Join(descriptions, " or ")
+}
+
+// anchor returns a unique HTML anchor for a PropertyJsonPointer, adding a numeric suffix to an anchor already taken.
+func (b *docsBuilder) anchor(pointer PropertyJsonPointer) string {
+	anchor := docsAnchor(pointer)
+
+	for i := 1; ; i++ {
+		if _, ok := b.anchors[anchor]; !ok {
+			break
+		}
+
+		anchor = fmt.Sprintf("%s-%d", docsAnchor(pointer), i)
+	}
+
+	b.anchors[anchor] = struct{}{}
+
+	return anchor
+}
+
+// docsAnchor returns the HTML anchor of a PropertyJsonPointer, e.g. properties-Memo-Heading.
+// Characters other than letters, digits, - and _ are replaced by -.
+func docsAnchor(pointer PropertyJsonPointer) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' {
+			return r
+		}
+
+		return '-'
+	}, strings.TrimPrefix(pointer.String(), ReferenceSeparator))
+}
+
+// docsEscape returns the text with Markdown syntax characters escaped, so that it is rendered literally.
+func docsEscape(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		if strings.ContainsRune("\\`*_[]<>|#", r) {
+			b.WriteRune('\\')
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// docsCode returns the text as a Markdown code span.
+func docsCode(s string) string {
+	if strings.Contains(s, "`") {
+		return "`` " + s + " ``"
+	}
+
+	return "`" + s + "`"
+}
+
+// docsCodeList returns the values as a comma separated list of Markdown code spans.
+func docsCodeList(values []string) string {
+	spans := make([]string, len(values))
+
+	for i, v := range values {
+		spans[i] = docsCode(v)
+	}
+
+	return strings.Join(spans, ", ")
+}
+
+// docsConstraints returns the constraints on the values of a property, with names prefixed.
+func docsConstraints(property *Property, prefix string) []*docsConstraint {
+	var constraints []*docsConstraint
+
+	add := func(name string, values ...string) {
+		if prefix != "" {
+			name = prefix + strings.ToLower(name[:1]) + name[1:]
+		}
+
+		constraints = append(constraints, &docsConstraint{
+			Name:   name,
+			Values: values,
+		})
+	}
+
+	if property.Format != nil {
+		add("Format", *property.Format)
+	}
+
+	if property.Pattern != nil && *property.Pattern != "" {
+		add("Pattern", *property.Pattern)
+	}
+
+	for _, v := range []struct {
+		name  string
+		value *int
+	}{
+		{"Minimum length", property.MinLength},
+		{"Maximum length", property.MaxLength},
+		{"Minimum items", property.MinItems},
+		{"Maximum items", property.MaxItems},
+		{"Minimum properties", property.MinProperties},
+		{"Maximum properties", property.MaxProperties},
+	} {
+		if v.value != nil {
+			add(v.name, fmt.Sprint(*v.value))
+		}
+	}
+
+	for _, v := range []struct {
+		name  string
+		value *json.Number
+	}{
+		{"Minimum", property.Minimum},
+		{"Exclusive minimum", property.ExclusiveMinimum},
+		{"Maximum", property.Maximum},
+		{"Exclusive maximum", property.ExclusiveMaximum},
+		{"Multiple of", property.MultipleOf},
+	} {
+		if v.value != nil {
+			add(v.name, v.value.String())
+		}
+	}
+
+	if property.UniqueItems != nil && *property.UniqueItems {
+		add("Unique items", "true")
+	}
+
+	if len(property.Enum) > 0 {
+		var values []string
+
+		for _, v := range property.Enum {
+			values = append(values, docsValue(v))
+		}
+
+		add("Allowed values", values...)
+	}
+
+	if property.Const != nil {
+		add("Value", docsValue(property.Const))
+	}
+
+	if property.Default != nil {
+		add("Default", docsValue(property.Default))
+	}
+
+	return constraints
+}
+
+// docsValue returns the JSON representation of a value, without quotes for strings.
+func docsValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	b, err := json.Marshal(v)
+
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(b)
+}
@@ -0,0 +1,214 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+const docsTestSchema = `{
+	"typeName": "Initech::TPS::Report",
+	"description": "A TPS report.",
+	"definitions": {
+		"Node": {"type": "object", "properties": {"Children": {"type": "array", "items": {"$ref": "#/definitions/Node"}}}}
+	},
+	"properties": {
+		"Id": {"type": "string", "pattern": "^[A-Z]+$"},
+		"Title": {"type": "string", "description": "The title.", "minLength": 1, "maxLength": 250},
+		"Memo": {"type": "object", "properties": {"Heading": {"type": "string", "enum": ["A", "B"]}}, "required": ["Heading"]},
+		"Pages": {"type": "integer", "maximum": 100, "default": 1},
+		"Tags": {"type": "array", "uniqueItems": true, "items": {"type": "string", "maxLength": 10}},
+		"Tree": {"$ref": "#/definitions/Node"}
+	},
+	"required": ["Title"],
+	"readOnlyProperties": ["/properties/Id"],
+	"createOnlyProperties": ["/properties/Memo"],
+	"writeOnlyProperties": ["/properties/Pages"]
+}`
+
+func TestGenerateMarkdown(t *testing.T) {
+	var resource cfschema.Resource
+
+	if err := json.Unmarshal([]byte(docsTestSchema), &resource); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	b, err := cfschema.GenerateMarkdown(&resource)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "# Initech::TPS::Report\n" +
+		"\n" +
+		"A TPS report.\n" +
+		"\n" +
+		"## <a id=\"properties\"></a>Properties\n" +
+		"\n" +
+		"### <a id=\"properties-Id\"></a>`Id`\n" +
+		"\n" +
+		"- Type: String\n" +
+		"- Read-only\n" +
+		"- Pattern: `^[A-Z]+$`\n" +
+		"\n" +
+		"### <a id=\"properties-Title\"></a>`Title`\n" +
+		"\n" +
+		"The title.\n" +
+		"\n" +
+		"- Type: String\n" +
+		"- Required\n" +
+		"- Minimum length: `1`\n" +
+		"- Maximum length: `250`\n" +
+		"\n" +
+		"### <a id=\"properties-Memo\"></a>`Memo`\n" +
+		"\n" +
+		"- Type: Object\n" +
+		"- Create-only\n" +
+		"- Attributes: [`Heading`](#properties-Memo-Heading)\n" +
+		"\n" +
+		"#### <a id=\"properties-Memo-Heading\"></a>`Memo/Heading`\n" +
+		"\n" +
+		"- Type: String\n" +
+		"- Required\n" +
+		"- Create-only\n" +
+		"- Allowed values: `A`, `B`\n" +
+		"\n" +
+		"### <a id=\"properties-Pages\"></a>`Pages`\n" +
+		"\n" +
+		"- Type: Integer\n" +
+		"- Write-only\n" +
+		"- Maximum: `100`\n" +
+		"- Default: `1`\n" +
+		"\n" +
+		"### <a id=\"properties-Tags\"></a>`Tags`\n" +
+		"\n" +
+		"- Type: Set of String\n" +
+		"- Unique items: `true`\n" +
+		"- Item maximum length: `10`\n" +
+		"\n" +
+		"### <a id=\"properties-Tree\"></a>`Tree`\n" +
+		"\n" +
+		"- Type: Object\n" +
+		"- Attributes: [`Children`](#properties-Tree-Children)\n" +
+		"\n" +
+		"#### <a id=\"properties-Tree-Children\"></a>`Tree/Children`\n" +
+		"\n" +
+		"- Type: List of Object\n" +
+		"- Recursive: see [`Tree`](#properties-Tree)\n"
+
+	if actual := string(b); actual != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+
+	if resource.Properties["Tree"].Ref == nil {
+		t.Error("expected Resource not to be modified")
+	}
+}
+
+func TestGenerateMarkdown_AnchorsAndEscaping(t *testing.T) {
+	var resource cfschema.Resource
+
+	if err := json.Unmarshal([]byte(`{
+		"typeName": "Initech::TPS::Report",
+		"description": "Reports <b>for</b> Lumbergh.",
+		"properties": {
+			"Foo.Bar": {"type": "string", "description": "Either a | b, *all* or _none_."},
+			"Foo Bar": {"type": "string"},
+			"Foo-Bar": {"type": "string", "description": "See [1] and # 2."}
+		}
+	}`), &resource); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	b, err := cfschema.GenerateMarkdown(&resource)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, expected := range []string{
+		"\nReports \\<b\\>for\\</b\\> Lumbergh.\n",
+		"<a id=\"properties-Foo-Bar\"></a>`Foo.Bar`\n\nEither a \\| b, \\*all\\* or \\_none\\_.\n",
+		"<a id=\"properties-Foo-Bar-1\"></a>`Foo Bar`\n",
+		"<a id=\"properties-Foo-Bar-2\"></a>`Foo-Bar`\n\nSee \\[1\\] and \\# 2.\n",
+	} {
+		if !strings.Contains(string(b), expected) {
+			t.Errorf("expected Markdown to contain %q, got:\n%s", expected, b)
+		}
+	}
+}
+
+func TestGenerateHTML(t *testing.T) {
+	var resource cfschema.Resource
+
+	if err := json.Unmarshal([]byte(docsTestSchema), &resource); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	b, err := cfschema.GenerateHTML(&resource)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, expected := range []string{
+		"<title>Initech::TPS::Report</title>",
+		`<h3 id="properties-Title"><code>Title</code></h3>`,
+		"<li>Maximum length: <code>250</code></li>",
+		`<h4 id="properties-Memo-Heading"><code>Memo/Heading</code></h4>`,
+		`<li>Attributes: <a href="#properties-Memo-Heading"><code>Heading</code></a></li>`,
+		`<li>Recursive: see <a href="#properties-Tree"><code>Tree</code></a></li>`,
+	} {
+		if !strings.Contains(string(b), expected) {
+			t.Errorf("expected HTML to contain %q, got:\n%s", expected, b)
+		}
+	}
+}
+
+func TestGenerateMarkdown_Testdata(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "AWS_*.json"))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	anchorPattern := regexp.MustCompile(`<a id="([^"]+)">`)
+	linkPattern := regexp.MustCompile(`\]\(#([^)]+)\)`)
+
+	for _, path := range paths {
+		path := path
+
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			resource := loadAndValidateResourceSchema(t, "provider.definition.schema.v1.json", filepath.Base(path))
+
+			b, err := cfschema.GenerateMarkdown(resource)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			anchors := make(map[string]bool)
+
+			for _, match := range anchorPattern.FindAllStringSubmatch(string(b), -1) {
+				if anchors[match[1]] {
+					t.Errorf("duplicate anchor: %s", match[1])
+				}
+
+				anchors[match[1]] = true
+			}
+
+			for _, match := range linkPattern.FindAllStringSubmatch(string(b), -1) {
+				if !anchors[match[1]] {
+					t.Errorf("link to missing anchor: %s", match[1])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Extensions represents JSON object keys that are not otherwise modeled by a type.
+//
+// Extensions are captured during unmarshalling and written back after all
+// modeled keys during marshalling, in key order, so that documents survive an
+// Unmarshal/Marshal round trip.
+//
+// Modeled keys whose values are empty or null, e.g. "required": [], would be omitted
+// when marshalling, so their values are captured in the EmptyValues field of the same
+// type and written back unless the modeled field has since been set.
+type Extensions map[string]json.RawMessage
+
+// Keys returns the extension keys in sorted order.
+func (e Extensions) Keys() []string {
+	if len(e) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(e))
+
+	for key := range e {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// knownKeysCache caches the JSON object keys modeled by each struct type.
+var knownKeysCache sync.Map
+
+// knownKeys returns the set of JSON object keys modeled by the struct type.
+func knownKeys(typ reflect.Type) map[string]struct{} {
+	if v, ok := knownKeysCache.Load(typ); ok {
+		return v.(map[string]struct{})
+	}
+
+	keys := make(map[string]struct{})
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+
+		keys[name] = struct{}{}
+	}
+
+	knownKeysCache.Store(typ, keys)
+
+	return keys
+}
+
+// unmarshalExtensions unmarshals the document into v, a pointer to a struct, and returns any object keys
+// not modeled by the struct and the values of any modeled keys that would be omitted when marshalling,
+// e.g. "required": [] or "default": null.
+func unmarshalExtensions(b []byte, v interface{}) (Extensions, Extensions, error) {
+	if err := json.Unmarshal(b, v); err != nil {
+		return nil, nil, err
+	}
+
+	var raw map[string]json.RawMessage
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, nil, err
+	}
+
+	value := reflect.ValueOf(v).Elem()
+	known := knownKeys(value.Type())
+	omitted := omittedKeys(value)
+
+	var extensions, emptyValues Extensions
+
+	for key, rawValue := range raw {
+		if _, ok := known[key]; !ok {
+			if extensions == nil {
+				extensions = make(Extensions)
+			}
+
+			extensions[key] = rawValue
+
+			continue
+		}
+
+		if _, ok := omitted[key]; ok {
+			if emptyValues == nil {
+				emptyValues = make(Extensions)
+			}
+
+			emptyValues[key] = rawValue
+		}
+	}
+
+	return extensions, emptyValues, nil
+}
+
+// omittedKeys returns the set of JSON object keys of the struct value whose fields are empty and omitempty.
+func omittedKeys(value reflect.Value) map[string]struct{} {
+	keys := make(map[string]struct{})
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+		if !field.IsExported() || name == "-" || !strings.Contains(","+options+",", ",omitempty,") {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		if isEmptyValue(value.Field(i)) {
+			keys[name] = struct{}{}
+		}
+	}
+
+	return keys
+}
+
+// isEmptyValue returns true if the value is omitted by the omitempty option of encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	}
+
+	return false
+}
+
+// marshalExtensions appends to the marshalled JSON object any empty values of modeled keys which
+// are not otherwise present, followed by any extensions.
+func marshalExtensions(b []byte, extensions, emptyValues Extensions) ([]byte, error) {
+	if len(extensions) == 0 && len(emptyValues) == 0 {
+		return b, nil
+	}
+
+	var present map[string]struct{}
+
+	if len(emptyValues) > 0 {
+		keys, err := objectKeys(b)
+
+		if err != nil {
+			return nil, err
+		}
+
+		present = make(map[string]struct{}, len(keys))
+
+		for _, key := range keys {
+			present[key] = struct{}{}
+		}
+	}
+
+	var buf bytes.Buffer
+
+	buf.Write(bytes.TrimSuffix(bytes.TrimSpace(b), []byte("}")))
+
+	write := func(key string, value json.RawMessage) error {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+
+		k, err := json.Marshal(key)
+
+		if err != nil {
+			return err
+		}
+
+		buf.Write(k)
+		buf.WriteByte(':')
+
+		return json.Compact(&buf, value)
+	}
+
+	for _, key := range emptyValues.Keys() {
+		if _, ok := present[key]; ok {
+			continue
+		}
+
+		if err := write(key, emptyValues[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, key := range extensions.Keys() {
+		if err := write(key, extensions[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
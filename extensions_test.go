@@ -0,0 +1,181 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestExtensions_Resource(t *testing.T) {
+	testCases := []struct {
+		TestDescription    string
+		Document           string
+		ExpectedExtensions []string
+		Expected           string
+	}{
+		{
+			TestDescription: "no extensions",
+			Document:        `{"typeName":"Initech::TPS::Report"}`,
+			Expected:        `{"typeName":"Initech::TPS::Report"}`,
+		},
+		{
+			TestDescription:    "resource extensions",
+			Document:           `{"x-zeta":1,"typeName":"Initech::TPS::Report","x-alpha":{"b":[true,null]}}`,
+			ExpectedExtensions: []string{"x-alpha", "x-zeta"},
+			Expected:           `{"typeName":"Initech::TPS::Report","x-alpha":{"b":[true,null]},"x-zeta":1}`,
+		},
+		{
+			TestDescription: "nested extensions",
+			Document:        `{"handlers":{"create":{"permissions":["a"],"x-h":"h"}},"properties":{"P":{"type":"string","x-p":"p","oneOf":[{"required":["P"],"x-s":"s"}]}},"tagging":{"taggable":true,"x-t":"t"}}`,
+			Expected:        `{"handlers":{"create":{"permissions":["a"],"x-h":"h"}},"properties":{"P":{"oneOf":[{"required":["P"],"x-s":"s"}],"type":"string","x-p":"p"}},"tagging":{"taggable":true,"x-t":"t"}}`,
+		},
+		{
+			TestDescription: "empty required",
+			Document:        `{"typeName":"Initech::TPS::Report","required":[]}`,
+			Expected:        `{"typeName":"Initech::TPS::Report","required":[]}`,
+		},
+		{
+			TestDescription: "empty properties",
+			Document:        `{"properties":{},"typeName":"Initech::TPS::Report"}`,
+			Expected:        `{"typeName":"Initech::TPS::Report","properties":{}}`,
+		},
+		{
+			TestDescription: "empty enum",
+			Document:        `{"properties":{"P":{"type":"string","enum":[]}}}`,
+			Expected:        `{"properties":{"P":{"type":"string","enum":[]}}}`,
+		},
+		{
+			TestDescription: "null default",
+			Document:        `{"properties":{"P":{"type":["string","null"],"default":null}}}`,
+			Expected:        `{"properties":{"P":{"type":["string","null"],"default":null}}}`,
+		},
+		{
+			TestDescription: "null const",
+			Document:        `{"properties":{"P":{"const":null}}}`,
+			Expected:        `{"properties":{"P":{"const":null}}}`,
+		},
+		{
+			TestDescription: "nested empty values",
+			Document:        `{"handlers":{"read":{"permissions":[]}},"properties":{"P":{"type":"object","properties":{},"required":[],"oneOf":[{"required":[]}]}},"tagging":{"permissions":[]}}`,
+			Expected:        `{"handlers":{"read":{"permissions":[]}},"properties":{"P":{"oneOf":[{"required":[]}],"type":"object","properties":{},"required":[]}},"tagging":{"permissions":[]}}`,
+		},
+		{
+			TestDescription:    "only extensions",
+			Document:           `{"x-only":"value"}`,
+			ExpectedExtensions: []string{"x-only"},
+			Expected:           `{"x-only":"value"}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			var resource cfschema.Resource
+
+			if err := json.Unmarshal([]byte(testCase.Document), &resource); err != nil {
+				t.Fatalf("unexpected unmarshal error: %s", err)
+			}
+
+			if actual, expected := resource.Extensions.Keys(), testCase.ExpectedExtensions; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("expected extensions (%v), got: %v", expected, actual)
+			}
+
+			b, err := json.Marshal(&resource)
+
+			if err != nil {
+				t.Fatalf("unexpected marshal error: %s", err)
+			}
+
+			if actual, expected := string(b), testCase.Expected; actual != expected {
+				t.Errorf("expected (%s), got: %s", expected, actual)
+			}
+		})
+	}
+}
+
+func TestExtensions_RoundTrip(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "AWS_*.json"))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	paths = append(paths, filepath.Join("testdata", "initech.tps.report.v1.json"), filepath.Join("testdata", "initech.tps.report.typeconfiguration.v1.json"))
+
+	for _, path := range paths {
+		path := path
+
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			document, err := os.ReadFile(path)
+
+			if err != nil {
+				t.Fatalf("unexpected error reading file (%s): %s", path, err)
+			}
+
+			var resource cfschema.Resource
+
+			if err := json.Unmarshal(document, &resource); err != nil {
+				t.Fatalf("unexpected unmarshal error: %s", err)
+			}
+
+			b, err := json.Marshal(&resource)
+
+			if err != nil {
+				t.Fatalf("unexpected marshal error: %s", err)
+			}
+
+			var expected, actual interface{}
+
+			if err := json.Unmarshal(document, &expected); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err := json.Unmarshal(b, &actual); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("round trip mismatch:\nexpected: %s\ngot: %s", document, b)
+			}
+
+			b2, err := json.Marshal(&resource)
+
+			if err != nil {
+				t.Fatalf("unexpected marshal error: %s", err)
+			}
+
+			if string(b) != string(b2) {
+				t.Error("expected stable marshalling, got differing output")
+			}
+		})
+	}
+}
+
+func TestExtensions_EmptyValuesModified(t *testing.T) {
+	var property cfschema.Property
+
+	if err := json.Unmarshal([]byte(`{"type":"object","required":[],"default":null}`), &property); err != nil {
+		t.Fatalf("unexpected unmarshal error: %s", err)
+	}
+
+	property.Required = []string{"Name"}
+	property.Default = map[string]interface{}{}
+
+	b, err := json.Marshal(&property)
+
+	if err != nil {
+		t.Fatalf("unexpected marshal error: %s", err)
+	}
+
+	if actual, expected := string(b), `{"default":{},"required":["Name"],"type":"object"}`; actual != expected {
+		t.Errorf("expected (%s), got: %s", expected, actual)
+	}
+}
@@ -0,0 +1,462 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// GoGeneratorOption configures GenerateGo.
+type GoGeneratorOption func(*goGenerator)
+
+// WithGoTypeName sets the name of the generated resource type.
+// The default is the last segment of the resource TypeName, e.g. Bucket for AWS::S3::Bucket.
+func WithGoTypeName(name string) GoGeneratorOption {
+	return func(g *goGenerator) {
+		g.typeName = name
+	}
+}
+
+// GenerateGo returns gofmt formatted Go source, in the named package, declaring a struct type for the
+// Resource properties and a named type for each of its definitions:
+//
+//   - Properties in required are value fields. Other properties are pointers, or slices and maps, with omitempty.
+//   - String properties with an enum are a named string type with a constant for each value.
+//   - References to definitions use the type of the definition. Other References are resolved and the
+//     target generated in place, as are nested objects, which are named after the type and property.
+//   - Objects without properties are maps, of the pattern property type if there is exactly one.
+//   - Properties with several types other than null are interface{}.
+//
+// Fields and definitions are declared in document order, see OrderedPropertyNames. The Resource may
+// or may not be expanded, as expanded properties use the definition of the first of their ResolvedRefs.
+func GenerateGo(resource *Resource, packageName string, opts ...GoGeneratorOption) ([]byte, error) {
+	if resource == nil {
+		return nil, fmt.Errorf("generating Go: Resource is required")
+	}
+
+	if !token.IsIdentifier(packageName) {
+		return nil, fmt.Errorf("generating Go: invalid package name (%s)", packageName)
+	}
+
+	g := &goGenerator{
+		definitions: make(map[string]string),
+		names:       make(map[string]struct{}),
+		resource:    resource,
+		visiting:    make(map[*Property]struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	var typeName string
+
+	if resource.TypeName != nil {
+		typeName = *resource.TypeName
+	}
+
+	if g.typeName == "" {
+		segments := strings.Split(typeName, "::")
+		g.typeName = goName(segments[len(segments)-1], false)
+	}
+
+	if !token.IsIdentifier(g.typeName) || !token.IsExported(g.typeName) {
+		return nil, fmt.Errorf("generating Go: invalid type name (%s)", g.typeName)
+	}
+
+	g.names[g.typeName] = struct{}{}
+
+	definitionNames := resource.OrderedDefinitionNames()
+
+	for _, name := range definitionNames {
+		g.definitions[name] = g.uniqueName(goName(name, false))
+	}
+
+	doc := fmt.Sprintf("%s represents the %s resource.", g.typeName, typeName)
+
+	if resource.Description != nil {
+		doc += "\n\n" + goComment(*resource.Description)
+	}
+
+	g.structDecl(g.typeName, doc, resource.Properties, resource.OrderedPropertyNames(), resource.Required, ReferenceSeparator+ReferenceTypeProperties)
+
+	for _, name := range definitionNames {
+		g.definitionDecl(name, resource.Definitions[name])
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated from the %s resource schema. DO NOT EDIT.\n\n", typeName)
+	fmt.Fprintf(&buf, "package %s\n", packageName)
+
+	for _, decl := range g.decls {
+		buf.WriteString("\n")
+		buf.WriteString(decl)
+	}
+
+	src, err := format.Source(buf.Bytes())
+
+	if err != nil {
+		return nil, fmt.Errorf("generating Go: formatting source: %w", err)
+	}
+
+	return src, nil
+}
+
+// goGenerator accumulates Go declarations while generating types for a Resource.
+type goGenerator struct {
+	decls       []string
+	definitions map[string]string
+	names       map[string]struct{}
+	resource    *Resource
+	typeName    string
+	visiting    map[*Property]struct{}
+}
+
+// definitionDecl declares the named type of a definition.
+func (g *goGenerator) definitionDecl(name string, property *Property) {
+	typeName := g.definitions[name]
+	location := joinJsonPointer([]string{ReferenceTypeDefinitions, name})
+	doc := fmt.Sprintf("%s represents the %s definition.", typeName, name)
+
+	if property != nil && property.Description != nil {
+		doc += "\n\n" + goComment(*property.Description)
+	}
+
+	// Definitions which are not References to other definitions are declared directly.
+	if property != nil && property.Ref == nil && len(property.ResolvedRefs) == 0 {
+		if properties := subschemaProperties(property); len(properties) > 0 && property.Type.Primary() != PropertyTypeArray {
+			g.structDecl(typeName, doc, properties, orderedSubschemaPropertyNames(property), property.Required, location)
+
+			return
+		}
+
+		if property.Type.Primary() == PropertyTypeString && goStringEnum(property.Enum) {
+			g.enumDecl(typeName, doc, property.Enum)
+
+			return
+		}
+	}
+
+	index := g.reserveDecl()
+	expr := g.typeExpr(property, typeName+"Value", location, name)
+
+	g.decls[index] = fmt.Sprintf("// %s\ntype %s %s\n", goCommentLines(doc), typeName, expr)
+}
+
+// enumDecl declares a named string type with a constant for each value.
+func (g *goGenerator) enumDecl(typeName, doc string, values []interface{}) {
+	var buf strings.Builder
+
+	fmt.Fprintf(&buf, "// %s\ntype %s string\n\nconst (\n", goCommentLines(doc), typeName)
+
+	constNames := make(map[string]struct{})
+
+	for _, value := range values {
+		s := value.(string)
+		constName := typeName + goName(s, true)
+
+		if s == "" {
+			constName = typeName + "Empty"
+		}
+
+		for i := 2; ; i++ {
+			if _, ok := constNames[constName]; !ok {
+				break
+			}
+
+			constName = fmt.Sprintf("%s%s%d", typeName, goName(s, true), i)
+		}
+
+		constNames[constName] = struct{}{}
+
+		fmt.Fprintf(&buf, "\t%s %s = %s\n", constName, typeName, strconv.Quote(s))
+	}
+
+	buf.WriteString(")\n")
+
+	g.decls = append(g.decls, buf.String())
+}
+
+// reserveDecl returns the index of a new declaration to be set later,
+// so that types are declared before the types nested in them.
+func (g *goGenerator) reserveDecl() int {
+	g.decls = append(g.decls, "")
+
+	return len(g.decls) - 1
+}
+
+// structDecl declares a struct type with a field for each property.
+func (g *goGenerator) structDecl(typeName, doc string, properties map[string]*Property, names, required []string, location string) {
+	index := g.reserveDecl()
+	fieldNames := make(map[string]struct{})
+
+	var buf strings.Builder
+
+	fmt.Fprintf(&buf, "// %s\ntype %s struct {\n", goCommentLines(doc), typeName)
+
+	for _, name := range names {
+		property := properties[name]
+		fieldName := goName(name, false)
+
+		for i := 2; ; i++ {
+			if _, ok := fieldNames[fieldName]; !ok {
+				break
+			}
+
+			fieldName = fmt.Sprintf("%s%d", goName(name, false), i)
+		}
+
+		fieldNames[fieldName] = struct{}{}
+
+		isRequired := false
+
+		for _, v := range required {
+			if v == name {
+				isRequired = true
+				break
+			}
+		}
+
+		propertyLocation := location + ReferenceSeparator + EscapeJsonPointerReferenceToken(name)
+		expr := g.typeExpr(property, typeName+fieldName, propertyLocation, "")
+		tag := name
+
+		if !isRequired {
+			tag += ",omitempty"
+
+			if !strings.HasPrefix(expr, "[]") && !strings.HasPrefix(expr, "map[") && expr != "interface{}" {
+				expr = "*" + expr
+			}
+		}
+
+		if property != nil && property.Description != nil {
+			fmt.Fprintf(&buf, "\t// %s\n", goCommentLines(goComment(*property.Description)))
+		}
+
+		fmt.Fprintf(&buf, "\t%s %s `json:%s`\n", fieldName, expr, strconv.Quote(tag))
+	}
+
+	buf.WriteString("}\n")
+
+	g.decls[index] = buf.String()
+}
+
+// typeExpr returns the Go type of a property, declaring any named types it needs.
+// New types are named typeName. If the property is the definition named self, it is not typed as that definition.
+func (g *goGenerator) typeExpr(property *Property, typeName, location, self string) string {
+	if property == nil {
+		return "interface{}"
+	}
+
+	for _, ref := range []*Reference{property.Ref, property.RecursiveRef, goFirstReference(property.ResolvedRefs)} {
+		if ref == nil {
+			continue
+		}
+
+		if name, ok := goDefinitionName(*ref); ok && name != self {
+			if goType, ok := g.definitions[name]; ok {
+				return goType
+			}
+		}
+	}
+
+	if property.RecursiveRef != nil {
+		return "interface{}"
+	}
+
+	if property.Ref != nil {
+		resolved := g.resource.resolvedProperty(property)
+
+		if resolved == nil {
+			return "interface{}"
+		}
+
+		if _, ok := g.visiting[resolved]; ok {
+			return "interface{}"
+		}
+
+		g.visiting[resolved] = struct{}{}
+		defer delete(g.visiting, resolved)
+
+		return g.typeExpr(resolved, typeName, location, "")
+	}
+
+	var types []string
+
+	for _, typ := range property.Type.Types() {
+		if typ != PropertyTypeNull {
+			types = append(types, typ)
+		}
+	}
+
+	if len(types) > 1 {
+		return "interface{}"
+	}
+
+	typ := property.Type.Primary()
+	properties := subschemaProperties(property)
+
+	if typ == "" {
+		switch {
+		case len(properties) > 0 || len(property.PatternProperties) > 0:
+			typ = PropertyTypeObject
+		case property.Items != nil:
+			typ = PropertyTypeArray
+		}
+	}
+
+	switch typ {
+	case PropertyTypeArray:
+		return "[]" + g.typeExpr(property.Items, typeName, location+ReferenceSeparator+PropertyJsonPointerWildcard, "")
+	case PropertyTypeBoolean:
+		return "bool"
+	case PropertyTypeInteger:
+		return "int64"
+	case PropertyTypeNumber:
+		return "float64"
+	case PropertyTypeObject:
+		if len(properties) > 0 {
+			name := g.uniqueName(typeName)
+			doc := fmt.Sprintf("%s represents the %s property.", name, location)
+
+			g.structDecl(name, doc, properties, orderedSubschemaPropertyNames(property), property.Required, location)
+
+			return name
+		}
+
+		if len(property.PatternProperties) == 1 {
+			for _, pattern := range property.OrderedPatternPropertyNames() {
+				return "map[string]" + g.typeExpr(property.PatternProperties[pattern], typeName, location+ReferenceSeparator+PropertyJsonPointerWildcard, "")
+			}
+		}
+
+		return "map[string]interface{}"
+	case PropertyTypeString:
+		if goStringEnum(property.Enum) {
+			name := g.uniqueName(typeName)
+
+			g.enumDecl(name, fmt.Sprintf("%s represents the allowed values of the %s property.", name, location), property.Enum)
+
+			return name
+		}
+
+		return "string"
+	}
+
+	return "interface{}"
+}
+
+// uniqueName returns the type name, suffixed with a number if already used, and marks it as used.
+func (g *goGenerator) uniqueName(name string) string {
+	unique := name
+
+	for i := 2; ; i++ {
+		if _, ok := g.names[unique]; !ok {
+			break
+		}
+
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+
+	g.names[unique] = struct{}{}
+
+	return unique
+}
+
+// orderedSubschemaPropertyNames returns the names of the properties returned by subschemaProperties,
+// in document order where known.
+func orderedSubschemaPropertyNames(property *Property) []string {
+	if len(property.Properties) > 0 {
+		return property.OrderedPropertyNames()
+	}
+
+	return sortedPropertyNames(subschemaProperties(property))
+}
+
+// goComment returns a description collapsed to a single line.
+func goComment(description string) string {
+	return strings.Join(strings.Fields(description), " ")
+}
+
+// goCommentLines returns a comment with each line after the first prefixed for use in Go source.
+func goCommentLines(comment string) string {
+	return strings.ReplaceAll(comment, "\n", "\n// ")
+}
+
+// goDefinitionName returns the name of the definition a local Reference targets, e.g. Tag for #/definitions/Tag.
+func goDefinitionName(ref Reference) (string, bool) {
+	if !ref.IsLocal() {
+		return "", false
+	}
+
+	path, err := ref.Path()
+
+	if err != nil || len(path) != 2 || path[0] != ReferenceTypeDefinitions {
+		return "", false
+	}
+
+	return path[1], true
+}
+
+// goFirstReference returns the first Reference of the list, or nil.
+func goFirstReference(refs []Reference) *Reference {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	return &refs[0]
+}
+
+// goName returns an exported Go identifier for a schema name. Characters other than letters and digits
+// separate words, which are capitalized. If titleCase is true, words entirely in upper case, e.g. NOT_STARTED,
+// are also lower cased after their first letter.
+func goName(s string, titleCase bool) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var sb strings.Builder
+
+	for _, word := range words {
+		runes := []rune(word)
+
+		if titleCase && strings.ToUpper(word) == word {
+			runes = []rune(strings.ToLower(word))
+		}
+
+		runes[0] = unicode.ToUpper(runes[0])
+
+		sb.WriteString(string(runes))
+	}
+
+	name := sb.String()
+
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) || !token.IsExported(name) {
+		name = "X" + name
+	}
+
+	return name
+}
+
+// goStringEnum returns true if the enum values are all strings.
+func goStringEnum(values []interface{}) bool {
+	if len(values) == 0 {
+		return false
+	}
+
+	for _, value := range values {
+		if _, ok := value.(string); !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,231 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestGenerateGo(t *testing.T) {
+	testCases := []struct {
+		TestDescription string
+		Schema          string
+		Options         []cfschema.GoGeneratorOption
+		Expected        string
+	}{
+		{
+			TestDescription: "properties",
+			Schema: `{
+				"typeName": "Initech::TPS::Report",
+				"description": "A TPS report.",
+				"properties": {
+					"Title": {"type": "string", "description": "The title."},
+					"Pages": {"type": "integer"},
+					"Score": {"type": "number"},
+					"Draft": {"type": "boolean"},
+					"Authors": {"type": "array", "items": {"type": "string"}},
+					"Labels": {"type": "object", "patternProperties": {".*": {"type": "string"}}},
+					"Settings": {"type": "object"},
+					"Value": {"type": ["string", "integer"]},
+					"Note": {"type": ["string", "null"]}
+				},
+				"required": ["Title", "Authors"]
+			}`,
+			Expected: `// Code generated from the Initech::TPS::Report resource schema. DO NOT EDIT.
+
+package test
+
+// Report represents the Initech::TPS::Report resource.
+//
+// A TPS report.
+type Report struct {
+	// The title.
+	Title    string                 ` + "`" + `json:"Title"` + "`" + `
+	Pages    *int64                 ` + "`" + `json:"Pages,omitempty"` + "`" + `
+	Score    *float64               ` + "`" + `json:"Score,omitempty"` + "`" + `
+	Draft    *bool                  ` + "`" + `json:"Draft,omitempty"` + "`" + `
+	Authors  []string               ` + "`" + `json:"Authors"` + "`" + `
+	Labels   map[string]string      ` + "`" + `json:"Labels,omitempty"` + "`" + `
+	Settings map[string]interface{} ` + "`" + `json:"Settings,omitempty"` + "`" + `
+	Value    interface{}            ` + "`" + `json:"Value,omitempty"` + "`" + `
+	Note     *string                ` + "`" + `json:"Note,omitempty"` + "`" + `
+}
+`,
+		},
+		{
+			TestDescription: "definitions and enums",
+			Schema: `{
+				"typeName": "Initech::TPS::Report",
+				"definitions": {
+					"Tag": {"type": "object", "properties": {"Key": {"type": "string"}, "Value": {"type": "string"}}, "required": ["Key"]},
+					"Status": {"type": "string", "enum": ["NOT_STARTED", "in-progress"]},
+					"Node": {"type": "object", "properties": {"Children": {"type": "array", "items": {"$ref": "#/definitions/Node"}}}}
+				},
+				"properties": {
+					"Tags": {"type": "array", "items": {"$ref": "#/definitions/Tag"}},
+					"Status": {"$ref": "#/definitions/Status"},
+					"Tree": {"$ref": "#/definitions/Node"},
+					"Memo": {"type": "object", "properties": {"Heading": {"type": "string", "enum": ["A", "B"]}}},
+					"Codes": {"type": "array", "items": {"type": "string", "enum": ["x.1", "y:2"]}}
+				}
+			}`,
+			Options: []cfschema.GoGeneratorOption{cfschema.WithGoTypeName("TPSReport")},
+			Expected: `// Code generated from the Initech::TPS::Report resource schema. DO NOT EDIT.
+
+package test
+
+// TPSReport represents the Initech::TPS::Report resource.
+type TPSReport struct {
+	Tags   []Tag             ` + "`" + `json:"Tags,omitempty"` + "`" + `
+	Status *Status           ` + "`" + `json:"Status,omitempty"` + "`" + `
+	Tree   *Node             ` + "`" + `json:"Tree,omitempty"` + "`" + `
+	Memo   *TPSReportMemo    ` + "`" + `json:"Memo,omitempty"` + "`" + `
+	Codes  []TPSReportCodes  ` + "`" + `json:"Codes,omitempty"` + "`" + `
+}
+
+// TPSReportMemo represents the /properties/Memo property.
+type TPSReportMemo struct {
+	Heading *TPSReportMemoHeading ` + "`" + `json:"Heading,omitempty"` + "`" + `
+}
+
+// TPSReportMemoHeading represents the allowed values of the /properties/Memo/Heading property.
+type TPSReportMemoHeading string
+
+const (
+	TPSReportMemoHeadingA TPSReportMemoHeading = "A"
+	TPSReportMemoHeadingB TPSReportMemoHeading = "B"
+)
+
+// TPSReportCodes represents the allowed values of the /properties/Codes/* property.
+type TPSReportCodes string
+
+const (
+	TPSReportCodesX1 TPSReportCodes = "x.1"
+	TPSReportCodesY2 TPSReportCodes = "y:2"
+)
+
+// Tag represents the Tag definition.
+type Tag struct {
+	Key   string  ` + "`" + `json:"Key"` + "`" + `
+	Value *string ` + "`" + `json:"Value,omitempty"` + "`" + `
+}
+
+// Status represents the Status definition.
+type Status string
+
+const (
+	StatusNotStarted Status = "NOT_STARTED"
+	StatusInProgress Status = "in-progress"
+)
+
+// Node represents the Node definition.
+type Node struct {
+	Children []Node ` + "`" + `json:"Children,omitempty"` + "`" + `
+}
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			for _, expand := range []bool{false, true} {
+				var resource cfschema.Resource
+
+				if err := json.Unmarshal([]byte(testCase.Schema), &resource); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if expand {
+					if err := resource.Expand(); err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+				}
+
+				src, err := cfschema.GenerateGo(&resource, "test", testCase.Options...)
+
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if actual, expected := string(src), testCase.Expected; normalizeSpace(actual) != normalizeSpace(expected) {
+					t.Errorf("expand (%t): expected:\n%s\ngot:\n%s", expand, testCase.Expected, actual)
+				}
+
+				typeCheckGo(t, src)
+			}
+		})
+	}
+}
+
+func TestGenerateGo_Testdata(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "AWS_*.json"))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, path := range paths {
+		path := path
+
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			resource := loadAndValidateResourceSchema(t, "provider.definition.schema.v1.json", filepath.Base(path))
+
+			src, err := cfschema.GenerateGo(resource, "test")
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			typeCheckGo(t, src)
+		})
+	}
+}
+
+func TestGenerateGo_Invalid(t *testing.T) {
+	resource := &cfschema.Resource{}
+
+	if _, err := cfschema.GenerateGo(nil, "test"); err == nil {
+		t.Error("expected error for nil Resource, got none")
+	}
+
+	if _, err := cfschema.GenerateGo(resource, "not-a-package"); err == nil {
+		t.Error("expected error for invalid package name, got none")
+	}
+
+	if _, err := cfschema.GenerateGo(resource, "test", cfschema.WithGoTypeName("report")); err == nil {
+		t.Error("expected error for unexported type name, got none")
+	}
+}
+
+// normalizeSpace collapses all runs of whitespace, so that gofmt alignment does not matter.
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// typeCheckGo fails the test if the Go source does not compile.
+func typeCheckGo(t *testing.T, src []byte) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "generated.go", src, parser.ParseComments)
+
+	if err != nil {
+		t.Fatalf("parsing generated source: %s\n%s", err, src)
+	}
+
+	if _, err := (&types.Config{}).Check("test", fset, []*ast.File{file}, nil); err != nil {
+		t.Fatalf("type checking generated source: %s\n%s", err, src)
+	}
+}
@@ -3,6 +3,10 @@
 
 package cfschema
 
+import (
+	"encoding/json"
+)
+
 const (
 	HandlerTypeCreate = "create"
 	HandlerTypeDelete = "delete"
@@ -12,7 +16,42 @@ const (
 )
 
 type Handler struct {
-	HandlerSchema    *HandlerSchema `json:"handlerSchema,omitempty"`
-	Permissions      []string       `json:"permissions,omitempty"`
-	TimeoutInMinutes int            `json:"timeoutInMinutes,omitempty"`
+	EmptyValues      Extensions      `json:"-"`
+	Extensions       Extensions      `json:"-"`
+	HandlerSchema    *HandlerSchema  `json:"handlerSchema,omitempty"`
+	Permissions      []string        `json:"permissions,omitempty"`
+	SourcePosition   *SourcePosition `json:"-"`
+	TimeoutInMinutes int             `json:"timeoutInMinutes,omitempty"`
+}
+
+// MarshalJSON is a custom JSON handler for Handler that preserves Extensions.
+func (h Handler) MarshalJSON() ([]byte, error) {
+	type handler Handler
+
+	b, err := json.Marshal(handler(h))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalExtensions(b, h.Extensions, h.EmptyValues)
+}
+
+// UnmarshalJSON is a custom JSON handler for Handler that captures Extensions.
+func (h *Handler) UnmarshalJSON(b []byte) error {
+	type handler Handler
+
+	var v handler
+
+	extensions, emptyValues, err := unmarshalExtensions(b, &v)
+
+	if err != nil {
+		return err
+	}
+
+	*h = Handler(v)
+	h.EmptyValues = emptyValues
+	h.Extensions = extensions
+
+	return nil
 }
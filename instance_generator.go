@@ -0,0 +1,442 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Instance is a generated instance document.
+//
+// For a deliberately invalid document, Keyword is the JSON Schema keyword it violates, e.g. maxLength,
+// and Pointer is the RFC 6901 JSON Pointer to the violating value in the document. For required and
+// additionalProperties violations, Pointer refers to the object. Both are empty for a valid document.
+type Instance struct {
+	Document string
+	Keyword  string
+	Pointer  string
+}
+
+// Valid returns true if the document was generated to be valid.
+func (i *Instance) Valid() bool {
+	return i != nil && i.Keyword == ""
+}
+
+// InstanceGenerator generates random instance documents of a Resource for property-based testing and fuzzing.
+//
+// Documents are generated as described by Resource SampleConfiguration, except that choices are made at
+// random: which optional properties, array items, pattern properties and oneOf or anyOf subschemas are
+// configured, and the values of properties within their constraints. Read-only properties are never included.
+// Patterns must be Go compatible regular expressions.
+//
+// The resource schema is trusted: the values of const, default, enum and examples keywords are used as
+// given and are not checked against the other keywords of the property, so Generate only returns valid
+// documents if those values are themselves valid.
+//
+// An InstanceGenerator produces the same documents for the same seed. It is not safe for concurrent use,
+// except for Fuzz.
+type InstanceGenerator struct {
+	rand    *rand.Rand
+	sampler *sampleGenerator
+}
+
+// NewInstanceGenerator returns an InstanceGenerator for the Resource, seeded with seed.
+func NewInstanceGenerator(resource *Resource, seed int64) (*InstanceGenerator, error) {
+	if resource == nil {
+		return nil, fmt.Errorf("generating instances: Resource is required")
+	}
+
+	expanded, err := resource.Expanded()
+
+	if err != nil {
+		return nil, fmt.Errorf("generating instances: %w", err)
+	}
+
+	return &InstanceGenerator{
+		rand:    rand.New(rand.NewSource(seed)),
+		sampler: newSampleGenerator(expanded),
+	}, nil
+}
+
+// Generate returns the next valid instance document.
+func (g *InstanceGenerator) Generate() (*Instance, error) {
+	return g.generate(g.rand, false)
+}
+
+// GenerateInvalid returns the next instance document which violates exactly one chosen keyword of the
+// resource schema, recorded in the Instance. Other keywords may incidentally be violated by the same value.
+func (g *InstanceGenerator) GenerateInvalid() (*Instance, error) {
+	return g.generate(g.rand, true)
+}
+
+// Fuzz returns a valid or invalid instance document determined only by the seed, for use with Go fuzzing:
+//
+//	f.Fuzz(func(t *testing.T, seed int64, invalid bool) {
+//		instance, err := generator.Fuzz(seed, invalid)
+//		// ...
+//	})
+func (g *InstanceGenerator) Fuzz(seed int64, invalid bool) (*Instance, error) {
+	return g.generate(rand.New(rand.NewSource(seed)), invalid)
+}
+
+// generate returns an instance document using the random source.
+func (g *InstanceGenerator) generate(rnd *rand.Rand, invalid bool) (*Instance, error) {
+	if g == nil {
+		return nil, fmt.Errorf("generating instance: InstanceGenerator is required")
+	}
+
+	sampler := *g.sampler
+	sampler.rand = rnd
+
+	object, err := sampler.object(sampler.root, nil, 0)
+
+	if err != nil {
+		return nil, fmt.Errorf("generating instance: %w", err)
+	}
+
+	var document interface{} = object
+	instance := &Instance{}
+
+	if invalid {
+		violations := sampleViolations(sampler.root, object, nil, func(v interface{}) { document = v })
+
+		if len(violations) == 0 {
+			return nil, fmt.Errorf("generating invalid instance: no keyword can be violated")
+		}
+
+		violation := violations[rnd.Intn(len(violations))]
+		violation.apply()
+
+		instance.Keyword = violation.keyword
+		instance.Pointer = joinJsonPointer(violation.path)
+	}
+
+	b, err := json.MarshalIndent(document, "", "  ")
+
+	if err != nil {
+		return nil, fmt.Errorf("generating instance: %w", err)
+	}
+
+	instance.Document = string(b)
+
+	return instance, nil
+}
+
+// sampleViolation is a change to a valid document which violates a keyword at the path.
+type sampleViolation struct {
+	apply   func()
+	keyword string
+	path    []string
+}
+
+// sampleViolations returns the possible violations of a property by the valid value at the path.
+// The set function replaces the value in the document; values are never modified in place.
+func sampleViolations(property *Property, value interface{}, path []string, set func(interface{})) []sampleViolation {
+	if property == nil || property.RecursiveRef != nil {
+		return nil
+	}
+
+	var violations []sampleViolation
+
+	add := func(keyword string, v interface{}) {
+		violations = append(violations, sampleViolation{
+			apply:   func() { set(v) },
+			keyword: keyword,
+			path:    path,
+		})
+	}
+
+	if property.Const != nil {
+		if v, ok := sampleOtherValue([]interface{}{property.Const}, value); ok {
+			add("const", v)
+		}
+	}
+
+	if len(property.Enum) > 0 {
+		if v, ok := sampleOtherValue(property.Enum, value); ok {
+			add("enum", v)
+		}
+	}
+
+	if v, ok := sampleOtherType(property); ok {
+		add("type", v)
+	}
+
+	switch value := value.(type) {
+	case string:
+		sampleStringViolations(property, value, add)
+	case []interface{}:
+		violations = append(violations, sampleArrayViolations(property, value, path, set, add)...)
+	case map[string]interface{}:
+		violations = append(violations, sampleObjectViolations(property, value, path, set, add)...)
+	default:
+		if v, ok := sampleFloat(value); ok {
+			sampleNumberViolations(property, v, add)
+		}
+	}
+
+	return violations
+}
+
+// sampleStringViolations adds the possible violations of a string property.
+func sampleStringViolations(property *Property, value string, add func(string, interface{})) {
+	length := utf8.RuneCountInString(value)
+
+	// Values from the resource schema, e.g. a default, may already violate the length.
+	if property.MinLength != nil && *property.MinLength > 0 {
+		if length >= *property.MinLength {
+			add("minLength", string([]rune(value)[:*property.MinLength-1]))
+		} else {
+			add("minLength", value)
+		}
+	}
+
+	if property.MaxLength != nil && *property.MaxLength >= 0 {
+		if length <= *property.MaxLength {
+			add("maxLength", value+strings.Repeat("x", *property.MaxLength+1-length))
+		} else {
+			add("maxLength", value)
+		}
+	}
+
+	if property.Pattern != nil {
+		if re, err := regexp.Compile(*property.Pattern); err == nil {
+			for _, candidate := range []string{"!", "", " ", "invalid value", "0"} {
+				if !re.MatchString(candidate) {
+					add("pattern", candidate)
+					break
+				}
+			}
+		}
+	}
+}
+
+// sampleNumberViolations adds the possible violations of a numeric property.
+func sampleNumberViolations(property *Property, value float64, add func(string, interface{})) {
+	if v, ok := sampleBound(property.Minimum); ok {
+		add("minimum", v-1)
+	}
+
+	if property.ExclusiveMinimum != nil {
+		add("exclusiveMinimum", *property.ExclusiveMinimum)
+	}
+
+	if v, ok := sampleBound(property.Maximum); ok {
+		add("maximum", v+1)
+	}
+
+	if property.ExclusiveMaximum != nil {
+		add("exclusiveMaximum", *property.ExclusiveMaximum)
+	}
+
+	if v, ok := sampleBound(property.MultipleOf); ok && v > 0 {
+		switch {
+		case property.Type.Includes(PropertyTypeNumber):
+			add("multipleOf", value+v/2)
+		case v > 1 && v == math.Trunc(v):
+			add("multipleOf", value+1)
+		}
+	}
+}
+
+// sampleArrayViolations adds the possible violations of an array property and returns those of its items.
+func sampleArrayViolations(property *Property, value []interface{}, path []string, set func(interface{}), add func(string, interface{})) []sampleViolation {
+	if property.MinItems != nil && *property.MinItems > 0 {
+		add("minItems", cloneSlice(value[:*property.MinItems-1]))
+	}
+
+	if property.MaxItems != nil && len(value) > 0 {
+		v := cloneSlice(value)
+
+		for i := 0; len(v) <= *property.MaxItems; i++ {
+			v = append(v, value[i%len(value)])
+		}
+
+		add("maxItems", v)
+	}
+
+	if property.UniqueItems != nil && *property.UniqueItems {
+		switch {
+		case len(value) > 0 && (property.MaxItems == nil || len(value) < *property.MaxItems):
+			add("uniqueItems", append(cloneSlice(value), value[0]))
+		case len(value) > 1:
+			v := cloneSlice(value)
+			v[len(v)-1] = v[0]
+
+			add("uniqueItems", v)
+		}
+	}
+
+	var violations []sampleViolation
+
+	for i, item := range value {
+		i := i
+
+		violations = append(violations, sampleViolations(property.Items, item, appendPath(path, fmt.Sprint(i)), func(item interface{}) {
+			v := cloneSlice(value)
+			v[i] = item
+
+			set(v)
+		})...)
+	}
+
+	return violations
+}
+
+// sampleObjectViolations adds the possible violations of an object property and returns those of its properties.
+func sampleObjectViolations(property *Property, value map[string]interface{}, path []string, set func(interface{}), add func(string, interface{})) []sampleViolation {
+	var violations []sampleViolation
+
+	required := sampleRequired(property)
+	sort.Strings(required)
+
+	for _, name := range required {
+		if _, ok := value[name]; !ok {
+			continue
+		}
+
+		v := cloneMap(value)
+		delete(v, name)
+
+		add("required", v)
+	}
+
+	properties := subschemaProperties(property)
+	patterns := property.OrderedPatternPropertyNames()
+
+	if property.AdditionalProperties != nil && !*property.AdditionalProperties {
+		name := "InvalidAdditionalProperty"
+
+		if _, ok := properties[name]; !ok && samplePatternProperty(property, patterns, name) == nil {
+			v := cloneMap(value)
+			v[name] = "invalid"
+
+			add("additionalProperties", v)
+		}
+	}
+
+	// Properties which are not configured are configured with the wrong type, unless they may be
+	// excluded by subschemas.
+	if len(property.AllOf) == 0 && len(property.AnyOf) == 0 && len(property.OneOf) == 0 && len(property.UnwrappedOneOf) == 0 {
+		for _, name := range orderedSubschemaPropertyNames(property) {
+			if _, ok := value[name]; ok {
+				continue
+			}
+
+			if typ, ok := sampleOtherType(properties[name]); ok {
+				v := cloneMap(value)
+				v[name] = typ
+
+				violations = append(violations, sampleViolation{
+					apply:   func() { set(v) },
+					keyword: "type",
+					path:    appendPath(path, name),
+				})
+			}
+		}
+	}
+
+	var names []string
+
+	for name := range value {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		name := name
+		schema, ok := properties[name]
+
+		if !ok {
+			schema = samplePatternProperty(property, patterns, name)
+		}
+
+		violations = append(violations, sampleViolations(schema, value[name], appendPath(path, name), func(item interface{}) {
+			v := cloneMap(value)
+			v[name] = item
+
+			set(v)
+		})...)
+	}
+
+	return violations
+}
+
+// sampleOtherType returns a value whose JSON type is not one of the types of the property.
+func sampleOtherType(property *Property) (interface{}, bool) {
+	if property == nil || len(property.Type.Types()) == 0 {
+		return nil, false
+	}
+
+	for _, candidate := range []struct {
+		typ   string
+		value interface{}
+	}{
+		{PropertyTypeBoolean, false},
+		{PropertyTypeString, "invalid"},
+		{PropertyTypeObject, map[string]interface{}{}},
+	} {
+		if !property.Type.Includes(candidate.typ) {
+			return candidate.value, true
+		}
+	}
+
+	return nil, false
+}
+
+// samplePatternProperty returns the first pattern property schema whose pattern matches the name.
+func samplePatternProperty(property *Property, patterns []string, name string) *Property {
+	for _, pattern := range patterns {
+		if re, err := regexp.Compile(pattern); err == nil && re.MatchString(name) {
+			return property.PatternProperties[pattern]
+		}
+	}
+
+	return nil
+}
+
+// sampleOtherValue returns a value of the same JSON type as value which is not in the list.
+func sampleOtherValue(values []interface{}, value interface{}) (interface{}, bool) {
+	if s, ok := value.(string); ok {
+		for i := 0; ; i++ {
+			if v := fmt.Sprintf("invalid-%s-%d", s, i); !sampleContains(values, v) {
+				return v, true
+			}
+		}
+	}
+
+	if f, ok := sampleFloat(value); ok {
+		for v := f + 1; ; v++ {
+			if !sampleContains(values, v) {
+				return v, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// sampleFloat returns the value of a generated or unmarshalled JSON number.
+func sampleFloat(value interface{}) (float64, bool) {
+	switch value := value.(type) {
+	case float64:
+		return value, true
+	case int64:
+		return float64(value), true
+	case json.Number:
+		f, err := value.Float64()
+
+		return f, err == nil
+	}
+
+	return 0, false
+}
@@ -0,0 +1,357 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+const instanceGeneratorTestCount = 200
+
+func TestInstanceGenerator(t *testing.T) {
+	testCases := []struct {
+		TestDescription string
+		Schema          string
+		ExpectContains  []string
+	}{
+		{
+			TestDescription: "pattern",
+			Schema: `{
+				"typeName": "Initech::TPS::Report",
+				"properties": {
+					"Code": {"type": "string", "pattern": "^(TPS|RPT)-[0-9]{3}[a-z]?$"},
+					"Name": {"type": "string", "pattern": "^[A-Za-z][A-Za-z0-9_]*$", "minLength": 3, "maxLength": 12}
+				},
+				"required": ["Code", "Name"],
+				"additionalProperties": false
+			}`,
+			ExpectContains: []string{`"TPS-`, `"RPT-`},
+		},
+		{
+			TestDescription: "arrays",
+			Schema: `{
+				"typeName": "Initech::TPS::Report",
+				"properties": {
+					"Pages": {"type": "array", "minItems": 2, "maxItems": 4, "uniqueItems": true, "items": {"type": "integer", "minimum": 1, "maximum": 5}},
+					"Reviewers": {"type": "array", "maxItems": 2, "uniqueItems": true, "items": {"type": "string", "enum": ["Lumbergh", "Milton", "Peter"]}}
+				},
+				"required": ["Pages"],
+				"additionalProperties": false
+			}`,
+			ExpectContains: []string{`"Reviewers"`},
+		},
+		{
+			TestDescription: "numbers",
+			Schema: `{
+				"typeName": "Initech::TPS::Report",
+				"properties": {
+					"Score": {"type": "number", "exclusiveMinimum": 0, "maximum": 1},
+					"Rate": {"type": "number", "multipleOf": 0.25, "minimum": -2, "maximum": 2},
+					"Count": {"type": ["integer", "null"], "multipleOf": 7, "maximum": 100}
+				},
+				"required": ["Score", "Rate", "Count"]
+			}`,
+			ExpectContains: []string{`"Count": null`},
+		},
+		{
+			TestDescription: "pattern properties",
+			Schema: `{
+				"typeName": "Initech::TPS::Report",
+				"definitions": {
+					"Labels": {
+						"type": "object",
+						"patternProperties": {"^[a-z]{2,8}$": {"type": "string", "maxLength": 4}},
+						"additionalProperties": false
+					}
+				},
+				"properties": {
+					"Labels": {"$ref": "#/definitions/Labels"}
+				},
+				"required": ["Labels"],
+				"additionalProperties": false
+			}`,
+			ExpectContains: []string{`"Labels": {}`},
+		},
+		{
+			TestDescription: "oneOf",
+			Schema: `{
+				"typeName": "Initech::TPS::Report",
+				"definitions": {
+					"Source": {
+						"type": "object",
+						"properties": {
+							"Bucket": {"type": "string"},
+							"Key": {"type": "string"},
+							"Inline": {"type": "string"}
+						},
+						"oneOf": [
+							{"required": ["Bucket", "Key"]},
+							{"required": ["Inline"]}
+						],
+						"additionalProperties": false
+					}
+				},
+				"properties": {
+					"Source": {"$ref": "#/definitions/Source"},
+					"Format": {"type": "string"}
+				},
+				"required": ["Source"],
+				"additionalProperties": false
+			}`,
+			ExpectContains: []string{`"Bucket"`, `"Inline"`},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			resourceSchema, err := cfschema.NewResourceJsonSchemaDocument(testCase.Schema)
+
+			if err != nil {
+				t.Fatalf("unexpected NewResourceJsonSchemaDocument() error: %s", err)
+			}
+
+			resource, err := resourceSchema.Resource()
+
+			if err != nil {
+				t.Fatalf("unexpected Resource() error: %s", err)
+			}
+
+			generator, err := cfschema.NewInstanceGenerator(resource, 1)
+
+			if err != nil {
+				t.Fatalf("unexpected NewInstanceGenerator() error: %s", err)
+			}
+
+			var documents []string
+
+			for i := 0; i < instanceGeneratorTestCount; i++ {
+				instance, err := generator.Generate()
+
+				if err != nil {
+					t.Fatalf("unexpected Generate() error: %s", err)
+				}
+
+				testInstanceValidation(t, resourceSchema, instance)
+
+				documents = append(documents, instance.Document)
+
+				instance, err = generator.GenerateInvalid()
+
+				if err != nil {
+					t.Fatalf("unexpected GenerateInvalid() error: %s", err)
+				}
+
+				testInstanceValidation(t, resourceSchema, instance)
+			}
+
+			all := strings.Join(documents, "\n")
+
+			for _, expected := range testCase.ExpectContains {
+				if !strings.Contains(all, expected) {
+					t.Errorf("expected a generated document to contain %s", expected)
+				}
+			}
+		})
+	}
+}
+
+func TestInstanceGeneratorSchemaValues(t *testing.T) {
+	// Enum values violating the length keywords are used as given.
+	resourceSchema, err := cfschema.NewResourceJsonSchemaDocument(`{
+		"typeName": "Initech::TPS::Report",
+		"properties": {
+			"Code": {"type": "string", "enum": ["A", "TOOLONG"], "minLength": 2, "maxLength": 3}
+		},
+		"required": ["Code"],
+		"additionalProperties": false
+	}`)
+
+	if err != nil {
+		t.Fatalf("unexpected NewResourceJsonSchemaDocument() error: %s", err)
+	}
+
+	resource, err := resourceSchema.Resource()
+
+	if err != nil {
+		t.Fatalf("unexpected Resource() error: %s", err)
+	}
+
+	generator, err := cfschema.NewInstanceGenerator(resource, 1)
+
+	if err != nil {
+		t.Fatalf("unexpected NewInstanceGenerator() error: %s", err)
+	}
+
+	for i := 0; i < instanceGeneratorTestCount; i++ {
+		instance, err := generator.GenerateInvalid()
+
+		if err != nil {
+			t.Fatalf("unexpected GenerateInvalid() error: %s", err)
+		}
+
+		testInstanceValidation(t, resourceSchema, instance)
+	}
+}
+
+func TestInstanceGeneratorSeed(t *testing.T) {
+	resource := loadAndValidateResourceSchema(t, "provider.definition.schema.v1.json", "initech.tps.report.v1.json")
+
+	generate := func(seed int64) []string {
+		generator, err := cfschema.NewInstanceGenerator(resource, seed)
+
+		if err != nil {
+			t.Fatalf("unexpected NewInstanceGenerator() error: %s", err)
+		}
+
+		var documents []string
+
+		for i := 0; i < 10; i++ {
+			instance, err := generator.Generate()
+
+			if err != nil {
+				t.Fatalf("unexpected Generate() error: %s", err)
+			}
+
+			documents = append(documents, instance.Document)
+		}
+
+		instance, err := generator.Fuzz(42, true)
+
+		if err != nil {
+			t.Fatalf("unexpected Fuzz() error: %s", err)
+		}
+
+		return append(documents, instance.Document)
+	}
+
+	first, second, other := generate(1), generate(1), generate(2)
+
+	if strings.Join(first, "\n") != strings.Join(second, "\n") {
+		t.Errorf("expected the same documents for the same seed")
+	}
+
+	if strings.Join(first[:10], "\n") == strings.Join(other[:10], "\n") {
+		t.Errorf("expected different documents for different seeds")
+	}
+
+	if first[10] != other[10] {
+		t.Errorf("expected the same Fuzz() document regardless of the generator seed")
+	}
+}
+
+func TestInstanceGeneratorTestdata(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "AWS_*.json"))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	paths = append(paths, filepath.Join("testdata", "initech.tps.report.v1.json"))
+
+	for _, path := range paths {
+		path := path
+
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			resourceSchema, err := cfschema.NewResourceJsonSchemaPath(path)
+
+			if err != nil {
+				t.Fatalf("unexpected NewResourceJsonSchemaPath() error: %s", err)
+			}
+
+			resource, err := resourceSchema.Resource()
+
+			if err != nil {
+				t.Fatalf("unexpected Resource() error: %s", err)
+			}
+
+			generator, err := cfschema.NewInstanceGenerator(resource, 1)
+
+			if err != nil {
+				t.Fatalf("unexpected NewInstanceGenerator() error: %s", err)
+			}
+
+			for i := 0; i < instanceGeneratorTestCount/4; i++ {
+				for _, invalid := range []bool{false, true} {
+					instance, err := generator.Fuzz(int64(i), invalid)
+
+					if err != nil {
+						t.Fatalf("unexpected Fuzz() error: %s", err)
+					}
+
+					testInstanceValidation(t, resourceSchema, instance)
+				}
+			}
+		})
+	}
+}
+
+func FuzzInstanceGenerator(f *testing.F) {
+	resourceSchema, err := cfschema.NewResourceJsonSchemaPath(filepath.Join("testdata", "initech.tps.report.v1.json"))
+
+	if err != nil {
+		f.Fatalf("unexpected NewResourceJsonSchemaPath() error: %s", err)
+	}
+
+	resource, err := resourceSchema.Resource()
+
+	if err != nil {
+		f.Fatalf("unexpected Resource() error: %s", err)
+	}
+
+	generator, err := cfschema.NewInstanceGenerator(resource, 0)
+
+	if err != nil {
+		f.Fatalf("unexpected NewInstanceGenerator() error: %s", err)
+	}
+
+	f.Add(int64(0), false)
+	f.Add(int64(1), true)
+
+	f.Fuzz(func(t *testing.T, seed int64, invalid bool) {
+		instance, err := generator.Fuzz(seed, invalid)
+
+		if err != nil {
+			t.Fatalf("unexpected Fuzz() error: %s", err)
+		}
+
+		testInstanceValidation(t, resourceSchema, instance)
+	})
+}
+
+// testInstanceValidation checks that a valid instance validates and that an invalid instance
+// fails validation for its keyword.
+func testInstanceValidation(t *testing.T, resourceSchema *cfschema.ResourceJsonSchema, instance *cfschema.Instance) {
+	t.Helper()
+
+	err := resourceSchema.ValidateConfigurationDocument(instance.Document)
+
+	if instance.Valid() {
+		if err != nil {
+			t.Fatalf("unexpected validation error: %s\n%s", err, instance.Document)
+		}
+
+		return
+	}
+
+	var validationErrors cfschema.ValidationErrors
+
+	if !errors.As(err, &validationErrors) {
+		t.Fatalf("expected %s validation error at %q, got %v\n%s", instance.Keyword, instance.Pointer, err, instance.Document)
+	}
+
+	for _, validationError := range validationErrors {
+		if validationError.Keyword == instance.Keyword && validationError.Pointer == instance.Pointer {
+			return
+		}
+	}
+
+	t.Fatalf("expected %s validation error at %q, got %s\n%s", instance.Keyword, instance.Pointer, err, instance.Document)
+}
@@ -0,0 +1,204 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	InstanceViolationTypeCreateOnly = "createOnly"
+	InstanceViolationTypeDeprecated = "deprecated"
+	InstanceViolationTypeReadOnly   = "readOnly"
+)
+
+// InstanceViolation represents a CloudFormation semantic rule violated by an instance document.
+type InstanceViolation struct {
+	Message string
+	Pointer PropertyJsonPointer
+	Type    string
+}
+
+// String returns a string representation of InstanceViolation.
+func (v *InstanceViolation) String() string {
+	if v == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s (%s): %s", v.Pointer, v.Type, v.Message)
+}
+
+// InstanceViolations is a list of InstanceViolation.
+type InstanceViolations []*InstanceViolation
+
+// ByPointer returns the violations grouped by PropertyJsonPointer.
+func (vs InstanceViolations) ByPointer() map[PropertyJsonPointer]InstanceViolations {
+	result := make(map[PropertyJsonPointer]InstanceViolations)
+
+	for _, v := range vs {
+		result[v.Pointer] = append(result[v.Pointer], v)
+	}
+
+	return result
+}
+
+// String returns a string representation of InstanceViolations.
+func (vs InstanceViolations) String() string {
+	var lines []string
+
+	for _, v := range vs {
+		lines = append(lines, v.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// ValidateInstance validates instance documents against the CloudFormation semantics of the resource schema
+// that are not expressible in JSON Schema.
+//
+// The operation must be HandlerTypeCreate or HandlerTypeUpdate. For create, values for ReadOnlyProperties in
+// the desired document are reported. For update, changes to CreateOnlyProperties between the prior and
+// desired documents are reported. For both operations, any use of DeprecatedProperties is reported.
+// A property with an explicit null value is specified. The prior document is ignored for create and may be empty.
+//
+// This does not perform JSON Schema validation; use ResourceJsonSchema.ValidateConfigurationDocument for that.
+func (r *Resource) ValidateInstance(operation string, prior, desired string) (InstanceViolations, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	desiredDocument, err := decodeInstanceDocument(desired)
+
+	if err != nil {
+		return nil, fmt.Errorf("parsing desired document: %w", err)
+	}
+
+	var violations InstanceViolations
+
+	switch operation {
+	case HandlerTypeCreate:
+		for _, ptr := range r.ReadOnlyProperties {
+			if values := instanceValues(desiredDocument, ptr.Path()); len(values) > 0 {
+				violations = append(violations, &InstanceViolation{
+					Message: "read-only property cannot be specified",
+					Pointer: ptr,
+					Type:    InstanceViolationTypeReadOnly,
+				})
+			}
+		}
+	case HandlerTypeUpdate:
+		priorDocument, err := decodeInstanceDocument(prior)
+
+		if err != nil {
+			return nil, fmt.Errorf("parsing prior document: %w", err)
+		}
+
+		for _, ptr := range r.CreateOnlyProperties {
+			path := ptr.Path()
+
+			if priorValues, desiredValues := instanceValues(priorDocument, path), instanceValues(desiredDocument, path); !reflect.DeepEqual(priorValues, desiredValues) {
+				violations = append(violations, &InstanceViolation{
+					Message: "create-only property cannot be changed",
+					Pointer: ptr,
+					Type:    InstanceViolationTypeCreateOnly,
+				})
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported operation: %s", operation)
+	}
+
+	for _, ptr := range r.DeprecatedProperties {
+		if values := instanceValues(desiredDocument, ptr.Path()); len(values) > 0 {
+			violations = append(violations, &InstanceViolation{
+				Message: "deprecated property is specified",
+				Pointer: ptr,
+				Type:    InstanceViolationTypeDeprecated,
+			})
+		}
+	}
+
+	return violations, nil
+}
+
+// decodeInstanceDocument decodes an instance document, preserving number precision.
+// An empty document decodes to nil.
+func decodeInstanceDocument(document string) (interface{}, error) {
+	if strings.TrimSpace(document) == "" {
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(document)))
+	decoder.UseNumber()
+
+	var result interface{}
+
+	if err := decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// instanceValues returns all values in the instance document at the path.
+// Arrays are traversed either explicitly via the wildcard token or implicitly when a property name is
+// applied to an array, as CloudFormation property JSON Pointers do not always include array wildcards.
+// A property present with a null value is included as a nil value.
+func instanceValues(document interface{}, path []string) []interface{} {
+	if len(path) == 0 {
+		return []interface{}{document}
+	}
+
+	if document == nil {
+		return nil
+	}
+
+	segment, rest := path[0], path[1:]
+
+	var result []interface{}
+
+	switch v := document.(type) {
+	case map[string]interface{}:
+		if segment == PropertyJsonPointerWildcard {
+			keys := make([]string, 0, len(v))
+
+			for key := range v {
+				keys = append(keys, key)
+			}
+
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				result = append(result, instanceValues(v[key], rest)...)
+			}
+
+			return result
+		}
+
+		if child, ok := v[segment]; ok {
+			return instanceValues(child, rest)
+		}
+	case []interface{}:
+		if segment == PropertyJsonPointerWildcard {
+			for _, element := range v {
+				result = append(result, instanceValues(element, rest)...)
+			}
+
+			return result
+		}
+
+		for _, element := range v {
+			result = append(result, instanceValues(element, path)...)
+		}
+
+		return result
+	}
+
+	return nil
+}
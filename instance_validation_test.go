@@ -0,0 +1,153 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"reflect"
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestResourceValidateInstance(t *testing.T) {
+	resource := &cfschema.Resource{
+		CreateOnlyProperties: cfschema.PropertyJsonPointers{
+			"/properties/Name",
+			"/properties/Rules/*/Id",
+		},
+		DeprecatedProperties: cfschema.PropertyJsonPointers{
+			"/properties/Legacy",
+		},
+		ReadOnlyProperties: cfschema.PropertyJsonPointers{
+			"/properties/Arn",
+			"/properties/Config/Status",
+			"/properties/Tags/Owner",
+		},
+	}
+
+	testCases := []struct {
+		TestDescription string
+		Operation       string
+		Prior           string
+		Desired         string
+		ExpectError     bool
+		Expected        map[cfschema.PropertyJsonPointer]string
+	}{
+		{
+			TestDescription: "unsupported operation",
+			Operation:       cfschema.HandlerTypeDelete,
+			Desired:         `{}`,
+			ExpectError:     true,
+		},
+		{
+			TestDescription: "invalid desired document",
+			Operation:       cfschema.HandlerTypeCreate,
+			Desired:         `{`,
+			ExpectError:     true,
+		},
+		{
+			TestDescription: "create valid",
+			Operation:       cfschema.HandlerTypeCreate,
+			Desired:         `{"Name": "test", "Config": {"Size": 1}}`,
+		},
+		{
+			TestDescription: "create read-only",
+			Operation:       cfschema.HandlerTypeCreate,
+			Desired:         `{"Arn": "arn", "Config": {"Status": "ACTIVE"}}`,
+			Expected: map[cfschema.PropertyJsonPointer]string{
+				"/properties/Arn":           cfschema.InstanceViolationTypeReadOnly,
+				"/properties/Config/Status": cfschema.InstanceViolationTypeReadOnly,
+			},
+		},
+		{
+			TestDescription: "create read-only in array without wildcard",
+			Operation:       cfschema.HandlerTypeCreate,
+			Desired:         `{"Tags": [{"Key": "k"}, {"Owner": "o"}]}`,
+			Expected: map[cfschema.PropertyJsonPointer]string{
+				"/properties/Tags/Owner": cfschema.InstanceViolationTypeReadOnly,
+			},
+		},
+		{
+			TestDescription: "create null",
+			Operation:       cfschema.HandlerTypeCreate,
+			Desired:         `{"Arn": null, "Config": null, "Legacy": null}`,
+			Expected: map[cfschema.PropertyJsonPointer]string{
+				"/properties/Arn":    cfschema.InstanceViolationTypeReadOnly,
+				"/properties/Legacy": cfschema.InstanceViolationTypeDeprecated,
+			},
+		},
+		{
+			TestDescription: "create deprecated",
+			Operation:       cfschema.HandlerTypeCreate,
+			Desired:         `{"Legacy": true}`,
+			Expected: map[cfschema.PropertyJsonPointer]string{
+				"/properties/Legacy": cfschema.InstanceViolationTypeDeprecated,
+			},
+		},
+		{
+			TestDescription: "update unchanged",
+			Operation:       cfschema.HandlerTypeUpdate,
+			Prior:           `{"Arn": "arn", "Name": "test", "Rules": [{"Id": 1}, {"Id": 2}]}`,
+			Desired:         `{"Arn": "arn", "Name": "test", "Rules": [{"Id": 1}, {"Id": 2}]}`,
+		},
+		{
+			TestDescription: "update create-only changed",
+			Operation:       cfschema.HandlerTypeUpdate,
+			Prior:           `{"Name": "test", "Rules": [{"Id": 1}, {"Id": 2}]}`,
+			Desired:         `{"Name": "changed", "Rules": [{"Id": 1}, {"Id": 3}]}`,
+			Expected: map[cfschema.PropertyJsonPointer]string{
+				"/properties/Name":       cfschema.InstanceViolationTypeCreateOnly,
+				"/properties/Rules/*/Id": cfschema.InstanceViolationTypeCreateOnly,
+			},
+		},
+		{
+			TestDescription: "update create-only removed",
+			Operation:       cfschema.HandlerTypeUpdate,
+			Prior:           `{"Name": "test"}`,
+			Desired:         `{}`,
+			Expected: map[cfschema.PropertyJsonPointer]string{
+				"/properties/Name": cfschema.InstanceViolationTypeCreateOnly,
+			},
+		},
+		{
+			TestDescription: "update create-only number precision",
+			Operation:       cfschema.HandlerTypeUpdate,
+			Prior:           `{"Name": 9007199254740993}`,
+			Desired:         `{"Name": 9007199254740992}`,
+			Expected: map[cfschema.PropertyJsonPointer]string{
+				"/properties/Name": cfschema.InstanceViolationTypeCreateOnly,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			violations, err := resource.ValidateInstance(testCase.Operation, testCase.Prior, testCase.Desired)
+
+			if err != nil && !testCase.ExpectError {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err == nil && testCase.ExpectError {
+				t.Fatal("expected error, got none")
+			}
+
+			var actual map[cfschema.PropertyJsonPointer]string
+
+			for ptr, vs := range violations.ByPointer() {
+				if actual == nil {
+					actual = make(map[cfschema.PropertyJsonPointer]string)
+				}
+
+				actual[ptr] = vs[0].Type
+			}
+
+			if expected := testCase.Expected; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("expected violations (%v), got: %v\n%s", expected, actual, violations)
+			}
+		})
+	}
+}
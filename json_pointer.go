@@ -0,0 +1,49 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"strings"
+)
+
+var (
+	jsonPointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	jsonPointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// EscapeJsonPointerReferenceToken escapes a JSON Pointer reference token per RFC 6901,
+// encoding '~' as '~0' and '/' as '~1'.
+func EscapeJsonPointerReferenceToken(token string) string {
+	return jsonPointerEscaper.Replace(token)
+}
+
+// UnescapeJsonPointerReferenceToken unescapes a JSON Pointer reference token per RFC 6901,
+// decoding '~1' as '/' and '~0' as '~'.
+func UnescapeJsonPointerReferenceToken(token string) string {
+	return jsonPointerUnescaper.Replace(token)
+}
+
+// joinJsonPointer returns a JSON Pointer from unescaped reference tokens.
+func joinJsonPointer(tokens []string) string {
+	var sb strings.Builder
+
+	for _, token := range tokens {
+		sb.WriteString(JsonPointerReferenceTokenSeparator)
+		sb.WriteString(EscapeJsonPointerReferenceToken(token))
+	}
+
+	return sb.String()
+}
+
+// splitJsonPointer returns the unescaped reference tokens of a JSON Pointer.
+// The leading separator, if any, is ignored.
+func splitJsonPointer(pointer string) []string {
+	tokens := strings.Split(strings.TrimPrefix(pointer, JsonPointerReferenceTokenSeparator), JsonPointerReferenceTokenSeparator)
+
+	for i, token := range tokens {
+		tokens[i] = UnescapeJsonPointerReferenceToken(token)
+	}
+
+	return tokens
+}
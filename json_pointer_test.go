@@ -0,0 +1,61 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestJsonPointerReferenceTokenEscaping(t *testing.T) {
+	testCases := []struct {
+		TestDescription string
+		Unescaped       string
+		Escaped         string
+	}{
+		{
+			TestDescription: "empty",
+		},
+		{
+			TestDescription: "no special characters",
+			Unescaped:       "test",
+			Escaped:         "test",
+		},
+		{
+			TestDescription: "separator",
+			Unescaped:       "a/b",
+			Escaped:         "a~1b",
+		},
+		{
+			TestDescription: "tilde",
+			Unescaped:       "m~n",
+			Escaped:         "m~0n",
+		},
+		{
+			TestDescription: "tilde followed by 1",
+			Unescaped:       "~1",
+			Escaped:         "~01",
+		},
+		{
+			TestDescription: "mixed",
+			Unescaped:       "~/~/",
+			Escaped:         "~0~1~0~1",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			if actual, expected := cfschema.EscapeJsonPointerReferenceToken(testCase.Unescaped), testCase.Escaped; actual != expected {
+				t.Errorf("expected escaped (%s), got: %s", expected, actual)
+			}
+
+			if actual, expected := cfschema.UnescapeJsonPointerReferenceToken(testCase.Escaped), testCase.Unescaped; actual != expected {
+				t.Errorf("expected unescaped (%s), got: %s", expected, actual)
+			}
+		})
+	}
+}
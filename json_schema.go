@@ -4,7 +4,6 @@
 package cfschema
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -24,23 +23,26 @@ type jsonSchema struct {
 func (s *jsonSchema) validateDocument(document string) error {
 	documentLoader := gojsonschema.NewStringLoader(document)
 
-	return s.validate(documentLoader)
+	return s.validate(documentLoader, []byte(document), "")
 }
 
 // validateJsonSchema validates the provided jsonSchema against the meta-schema.
 func (s *jsonSchema) validateJsonSchema(s2 jsonSchema) error {
-	return s.validate(s2.loader)
+	return s.validate(s2.loader, s2.source, s2.path)
 }
 
 // validatePath validates the document at the provided file path against the meta-schema.
 func (s *jsonSchema) validatePath(path string) error {
 	documentLoader := gojsonschema.NewReferenceLoader("file://" + path)
 
-	return s.validate(documentLoader)
+	return s.validate(documentLoader, nil, path)
 }
 
 // validate performs common validation logic.
-func (s *jsonSchema) validate(loader gojsonschema.JSONLoader) error {
+//
+// Any validation failures are returned as ValidationErrors, located in the source of the
+// validated document. If no source is provided, it is read from the file path.
+func (s *jsonSchema) validate(loader gojsonschema.JSONLoader, source []byte, path string) error {
 	result, err := s.schema.Validate(loader)
 
 	if err != nil {
@@ -48,13 +50,22 @@ func (s *jsonSchema) validate(loader gojsonschema.JSONLoader) error {
 	}
 
 	if !result.Valid() {
-		var errs []error
+		if source == nil && path != "" {
+			source, _ = os.ReadFile(path)
+		}
+
+		positions := newSourcePositions(source, path)
+
+		var errs ValidationErrors
 
 		for _, resultError := range result.Errors() {
-			errs = append(errs, errors.New(resultError.String()))
+			validationError := newValidationError(resultError)
+			validationError.Position = positions[validationError.Pointer]
+
+			errs = append(errs, validationError)
 		}
 
-		return fmt.Errorf("validation errors: %w", errors.Join(errs...))
+		return fmt.Errorf("validation errors: %w", errs)
 	}
 
 	return nil
@@ -0,0 +1,157 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	LintSeverityError   = "error"
+	LintSeverityWarning = "warning"
+)
+
+// LintRule represents a check over a Resource.
+type LintRule interface {
+	// Check returns any results for the Resource. Results without a Severity are
+	// assigned the rule Severity and results without a Rule are assigned the rule ID.
+	Check(resource *Resource) LintResults
+
+	// Description returns a human readable description of the rule.
+	Description() string
+
+	// ID returns the unique identifier of the rule.
+	ID() string
+
+	// Severity returns the default severity of results from the rule.
+	Severity() string
+}
+
+// LintResult represents a single problem found by a LintRule.
+type LintResult struct {
+	Message string
+
+	// Location is the RFC 6901 JSON Pointer to the offending element in the resource schema document,
+	// e.g. /handlers/create or /definitions/Tag/properties/Key, if any.
+	Location string
+
+	// Pointer is the PropertyJsonPointer of the offending property, e.g. /properties/Tag/Key, if any.
+	Pointer PropertyJsonPointer
+
+	Rule     string
+	Severity string
+}
+
+// String returns a string representation of LintResult.
+func (r *LintResult) String() string {
+	if r == nil {
+		return ""
+	}
+
+	location := string(r.Pointer)
+
+	if location == "" {
+		location = r.Location
+	}
+
+	if location == "" {
+		return fmt.Sprintf("%s [%s]: %s", r.Severity, r.Rule, r.Message)
+	}
+
+	return fmt.Sprintf("%s [%s] %s: %s", r.Severity, r.Rule, location, r.Message)
+}
+
+// LintResults is a list of LintResult.
+type LintResults []*LintResult
+
+// HasErrors returns true if any result has error severity.
+func (rs LintResults) HasErrors() bool {
+	for _, r := range rs {
+		if r.Severity == LintSeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns a string representation of LintResults.
+func (rs LintResults) String() string {
+	var lines []string
+
+	for _, r := range rs {
+		lines = append(lines, r.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Lint checks the Resource against the provided rules and returns all results in rule order.
+// If no rules are provided, DefaultLintRules are used.
+func (r *Resource) Lint(rules ...LintRule) LintResults {
+	if r == nil {
+		return nil
+	}
+
+	if len(rules) == 0 {
+		rules = DefaultLintRules()
+	}
+
+	var results LintResults
+
+	for _, rule := range rules {
+		for _, result := range rule.Check(r) {
+			if result == nil {
+				continue
+			}
+
+			if result.Rule == "" {
+				result.Rule = rule.ID()
+			}
+
+			if result.Severity == "" {
+				result.Severity = rule.Severity()
+			}
+
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
+// lintRule is a LintRule implemented by a function.
+type lintRule struct {
+	check       func(*Resource) LintResults
+	description string
+	id          string
+	severity    string
+}
+
+// NewLintRule returns a LintRule implemented by the check function.
+func NewLintRule(id, severity, description string, check func(*Resource) LintResults) LintRule {
+	return &lintRule{
+		check:       check,
+		description: description,
+		id:          id,
+		severity:    severity,
+	}
+}
+
+func (r *lintRule) Check(resource *Resource) LintResults {
+	return r.check(resource)
+}
+
+func (r *lintRule) Description() string {
+	return r.description
+}
+
+func (r *lintRule) ID() string {
+	return r.id
+}
+
+func (r *lintRule) Severity() string {
+	return r.severity
+}
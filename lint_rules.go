@@ -0,0 +1,276 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	LintRuleAdditionalPropertiesFalse             = "additional-properties-false"
+	LintRuleHandlerPermissions                    = "handler-permissions"
+	LintRulePrimaryIdentifierExists               = "primary-identifier-exists"
+	LintRulePrimaryIdentifierNotWriteOnly         = "primary-identifier-not-write-only"
+	LintRulePrimaryIdentifierReadOnlyOrCreateOnly = "primary-identifier-read-only-or-create-only"
+	LintRuleReadOnlyNotCreateOnly                 = "read-only-not-create-only"
+	LintRuleReadOnlyNotRequired                   = "read-only-not-required"
+	LintRuleReadOnlyNotWriteOnly                  = "read-only-not-write-only"
+	LintRuleRequiredHandlers                      = "required-handlers"
+	LintRuleTagPropertyExists                     = "tag-property-exists"
+)
+
+// DefaultLintRules returns the built-in rules, which mirror the resource schema checks
+// performed by the CloudFormation CLI.
+func DefaultLintRules() []LintRule {
+	return []LintRule{
+		NewLintRule(LintRulePrimaryIdentifierExists, LintSeverityError, "primaryIdentifier properties must exist", lintPrimaryIdentifierExists),
+		NewLintRule(LintRulePrimaryIdentifierNotWriteOnly, LintSeverityError, "primaryIdentifier properties must not be writeOnlyProperties", lintPrimaryIdentifierNotWriteOnly),
+		NewLintRule(LintRulePrimaryIdentifierReadOnlyOrCreateOnly, LintSeverityWarning, "primaryIdentifier properties should be readOnlyProperties or createOnlyProperties", lintPrimaryIdentifierReadOnlyOrCreateOnly),
+		NewLintRule(LintRuleReadOnlyNotRequired, LintSeverityError, "readOnlyProperties must not be required", lintReadOnlyNotRequired),
+		NewLintRule(LintRuleReadOnlyNotWriteOnly, LintSeverityError, "readOnlyProperties must not be writeOnlyProperties", lintReadOnlyNotWriteOnly),
+		NewLintRule(LintRuleReadOnlyNotCreateOnly, LintSeverityWarning, "readOnlyProperties should not be createOnlyProperties", lintReadOnlyNotCreateOnly),
+		NewLintRule(LintRuleHandlerPermissions, LintSeverityError, "handlers must specify permissions", lintHandlerPermissions),
+		NewLintRule(LintRuleRequiredHandlers, LintSeverityWarning, "create, read and delete handlers should be specified", lintRequiredHandlers),
+		NewLintRule(LintRuleTagPropertyExists, LintSeverityError, "tagging tagProperty must exist", lintTagPropertyExists),
+		NewLintRule(LintRuleAdditionalPropertiesFalse, LintSeverityWarning, "objects should set additionalProperties to false", lintAdditionalPropertiesFalse),
+	}
+}
+
+func lintPrimaryIdentifierExists(r *Resource) LintResults {
+	var results LintResults
+
+	for _, ptr := range r.PrimaryIdentifier {
+		if !r.hasPointer(ptr) {
+			results = append(results, &LintResult{
+				Message: "primaryIdentifier property not found",
+				Pointer: ptr,
+			})
+		}
+	}
+
+	return results
+}
+
+func lintPrimaryIdentifierNotWriteOnly(r *Resource) LintResults {
+	var results LintResults
+
+	for _, ptr := range r.PrimaryIdentifier {
+		if r.WriteOnlyProperties.ContainsPath(ptr.Path()) {
+			results = append(results, &LintResult{
+				Message: "primaryIdentifier property is also in writeOnlyProperties",
+				Pointer: ptr,
+			})
+		}
+	}
+
+	return results
+}
+
+func lintPrimaryIdentifierReadOnlyOrCreateOnly(r *Resource) LintResults {
+	var results LintResults
+
+	for _, ptr := range r.PrimaryIdentifier {
+		if path := ptr.Path(); !r.ReadOnlyProperties.ContainsPath(path) && !r.CreateOnlyProperties.ContainsPath(path) {
+			results = append(results, &LintResult{
+				Message: "primaryIdentifier property is neither in readOnlyProperties nor createOnlyProperties",
+				Pointer: ptr,
+			})
+		}
+	}
+
+	return results
+}
+
+func lintReadOnlyNotRequired(r *Resource) LintResults {
+	var results LintResults
+
+	for _, ptr := range r.ReadOnlyProperties {
+		path := ptr.Path()
+		name := path[len(path)-1]
+
+		var required bool
+
+		if len(path) == 1 {
+			required = r.IsRequired(name)
+		} else if _, parents, err := r.LookupPointer(ptr); err == nil {
+			required = parents[len(parents)-1].IsRequired(name)
+		}
+
+		if required {
+			results = append(results, &LintResult{
+				Message: "readOnlyProperties property is also required",
+				Pointer: ptr,
+			})
+		}
+	}
+
+	return results
+}
+
+func lintReadOnlyNotWriteOnly(r *Resource) LintResults {
+	var results LintResults
+
+	for _, ptr := range r.ReadOnlyProperties {
+		if r.WriteOnlyProperties.ContainsPath(ptr.Path()) {
+			results = append(results, &LintResult{
+				Message: "readOnlyProperties property is also in writeOnlyProperties",
+				Pointer: ptr,
+			})
+		}
+	}
+
+	return results
+}
+
+func lintReadOnlyNotCreateOnly(r *Resource) LintResults {
+	var results LintResults
+
+	for _, ptr := range r.ReadOnlyProperties {
+		if r.CreateOnlyProperties.ContainsPath(ptr.Path()) {
+			results = append(results, &LintResult{
+				Message: "readOnlyProperties property is also in createOnlyProperties",
+				Pointer: ptr,
+			})
+		}
+	}
+
+	return results
+}
+
+func lintHandlerPermissions(r *Resource) LintResults {
+	var results LintResults
+
+	for _, handlerType := range sortedHandlerTypes(r.Handlers) {
+		if handler := r.Handlers[handlerType]; handler == nil || len(handler.Permissions) == 0 {
+			results = append(results, &LintResult{
+				Message:  fmt.Sprintf("%s handler has no permissions", handlerType),
+				Location: joinJsonPointer([]string{"handlers", handlerType}),
+			})
+		}
+	}
+
+	return results
+}
+
+func lintRequiredHandlers(r *Resource) LintResults {
+	if len(r.Handlers) == 0 {
+		return nil
+	}
+
+	var results LintResults
+
+	for _, handlerType := range []string{HandlerTypeCreate, HandlerTypeRead, HandlerTypeDelete} {
+		if _, ok := r.Handlers[handlerType]; !ok {
+			results = append(results, &LintResult{
+				Message:  fmt.Sprintf("%s handler not specified", handlerType),
+				Location: joinJsonPointer([]string{"handlers"}),
+			})
+		}
+	}
+
+	return results
+}
+
+func lintTagPropertyExists(r *Resource) LintResults {
+	if r.Tagging == nil || r.Tagging.TagProperty == nil {
+		return nil
+	}
+
+	if ptr := r.Tagging.TagProperty; !r.hasPointer(*ptr) {
+		return LintResults{
+			{
+				Message: "tagProperty property not found",
+				Pointer: *ptr,
+			},
+		}
+	}
+
+	return nil
+}
+
+func lintAdditionalPropertiesFalse(r *Resource) LintResults {
+	var results LintResults
+
+	if r.AdditionalProperties == nil || *r.AdditionalProperties {
+		results = append(results, &LintResult{
+			Message:  "resource additionalProperties is not false",
+			Location: joinJsonPointer([]string{"additionalProperties"}),
+		})
+	}
+
+	// The path of a property is nil within definitions, which have no PropertyJsonPointer.
+	var walk func([]string, []string, *Property)
+
+	walk = func(location, path []string, property *Property) {
+		if property == nil {
+			return
+		}
+
+		if len(property.Properties) > 0 && (property.AdditionalProperties == nil || *property.AdditionalProperties) {
+			result := &LintResult{
+				Message:  "object additionalProperties is not false",
+				Location: joinJsonPointer(location),
+			}
+
+			if path != nil {
+				result.Pointer = NewPropertyJsonPointer(path...)
+			}
+
+			results = append(results, result)
+		}
+
+		var itemsPath []string
+
+		if path != nil {
+			itemsPath = appendPath(path, PropertyJsonPointerWildcard)
+		}
+
+		walk(appendPath(location, "items"), itemsPath, property.Items)
+
+		for _, name := range sortedPropertyNames(property.Properties) {
+			var nestedPath []string
+
+			if path != nil {
+				nestedPath = appendPath(path, name)
+			}
+
+			walk(appendPath(location, ReferenceTypeProperties, name), nestedPath, property.Properties[name])
+		}
+	}
+
+	for _, name := range sortedPropertyNames(r.Definitions) {
+		walk([]string{ReferenceTypeDefinitions, name}, nil, r.Definitions[name])
+	}
+
+	for _, name := range sortedPropertyNames(r.Properties) {
+		walk([]string{ReferenceTypeProperties, name}, []string{name}, r.Properties[name])
+	}
+
+	return results
+}
+
+func sortedHandlerTypes(handlers map[string]*Handler) []string {
+	keys := make([]string, 0, len(handlers))
+
+	for key := range handlers {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func sortedPropertyNames(properties map[string]*Property) []string {
+	keys := make([]string, 0, len(properties))
+
+	for key := range properties {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,248 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"reflect"
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestDefaultLintRules(t *testing.T) {
+	testCases := []struct {
+		TestDescription   string
+		Resource          *cfschema.Resource
+		Rule              string
+		Expected          []cfschema.PropertyJsonPointer
+		ExpectedLocations []string
+	}{
+		{
+			TestDescription: "primary identifier exists",
+			Resource: &cfschema.Resource{
+				Definitions: map[string]*cfschema.Property{
+					"Config": {
+						Properties: map[string]*cfschema.Property{
+							"Id": {Type: testType(cfschema.PropertyTypeString)},
+						},
+					},
+				},
+				PrimaryIdentifier: cfschema.PropertyJsonPointers{"/properties/Config/Id", "/properties/Config/Name", "/properties/Missing"},
+				Properties: map[string]*cfschema.Property{
+					"Config": {Ref: testReference("#/definitions/Config")},
+				},
+			},
+			Rule:     cfschema.LintRulePrimaryIdentifierExists,
+			Expected: []cfschema.PropertyJsonPointer{"/properties/Config/Name", "/properties/Missing"},
+		},
+		{
+			TestDescription: "primary identifier exists in array",
+			Resource: &cfschema.Resource{
+				PrimaryIdentifier: cfschema.PropertyJsonPointers{"/properties/Items/*/Id", "/properties/Items/Id", "/properties/Items/Name"},
+				Properties: map[string]*cfschema.Property{
+					"Items": {
+						Type: testType(cfschema.PropertyTypeArray),
+						Items: &cfschema.Property{
+							Properties: map[string]*cfschema.Property{
+								"Id": {Type: testType(cfschema.PropertyTypeString)},
+							},
+						},
+					},
+				},
+			},
+			Rule:     cfschema.LintRulePrimaryIdentifierExists,
+			Expected: []cfschema.PropertyJsonPointer{"/properties/Items/Name"},
+		},
+		{
+			TestDescription: "primary identifier not write-only",
+			Resource: &cfschema.Resource{
+				PrimaryIdentifier:   cfschema.PropertyJsonPointers{"/properties/Id", "/properties/Name"},
+				WriteOnlyProperties: cfschema.PropertyJsonPointers{"/properties/Name"},
+			},
+			Rule:     cfschema.LintRulePrimaryIdentifierNotWriteOnly,
+			Expected: []cfschema.PropertyJsonPointer{"/properties/Name"},
+		},
+		{
+			TestDescription: "primary identifier read-only or create-only",
+			Resource: &cfschema.Resource{
+				CreateOnlyProperties: cfschema.PropertyJsonPointers{"/properties/Name"},
+				PrimaryIdentifier:    cfschema.PropertyJsonPointers{"/properties/Id", "/properties/Name", "/properties/Other"},
+				ReadOnlyProperties:   cfschema.PropertyJsonPointers{"/properties/Id"},
+			},
+			Rule:     cfschema.LintRulePrimaryIdentifierReadOnlyOrCreateOnly,
+			Expected: []cfschema.PropertyJsonPointer{"/properties/Other"},
+		},
+		{
+			TestDescription: "read-only not required",
+			Resource: &cfschema.Resource{
+				Properties: map[string]*cfschema.Property{
+					"Config": {
+						Properties: map[string]*cfschema.Property{
+							"Arn":  {Type: testType(cfschema.PropertyTypeString)},
+							"Name": {Type: testType(cfschema.PropertyTypeString)},
+						},
+						Required: []string{"Arn"},
+					},
+				},
+				ReadOnlyProperties: cfschema.PropertyJsonPointers{"/properties/Id", "/properties/Config/Arn", "/properties/Config/Name"},
+				Required:           []string{"Id"},
+			},
+			Rule:     cfschema.LintRuleReadOnlyNotRequired,
+			Expected: []cfschema.PropertyJsonPointer{"/properties/Id", "/properties/Config/Arn"},
+		},
+		{
+			TestDescription: "read-only not write-only",
+			Resource: &cfschema.Resource{
+				ReadOnlyProperties:  cfschema.PropertyJsonPointers{"/properties/Id", "/properties/Name"},
+				WriteOnlyProperties: cfschema.PropertyJsonPointers{"/properties/Name"},
+			},
+			Rule:     cfschema.LintRuleReadOnlyNotWriteOnly,
+			Expected: []cfschema.PropertyJsonPointer{"/properties/Name"},
+		},
+		{
+			TestDescription: "read-only not create-only",
+			Resource: &cfschema.Resource{
+				CreateOnlyProperties: cfschema.PropertyJsonPointers{"/properties/Id"},
+				ReadOnlyProperties:   cfschema.PropertyJsonPointers{"/properties/Id", "/properties/Name"},
+			},
+			Rule:     cfschema.LintRuleReadOnlyNotCreateOnly,
+			Expected: []cfschema.PropertyJsonPointer{"/properties/Id"},
+		},
+		{
+			TestDescription: "handler permissions",
+			Resource: &cfschema.Resource{
+				Handlers: map[string]*cfschema.Handler{
+					cfschema.HandlerTypeCreate: {Permissions: []string{"initech:CreateReport"}},
+					cfschema.HandlerTypeDelete: {},
+					cfschema.HandlerTypeRead:   {Permissions: []string{}},
+				},
+			},
+			Rule:              cfschema.LintRuleHandlerPermissions,
+			ExpectedLocations: []string{"/handlers/delete", "/handlers/read"},
+		},
+		{
+			TestDescription: "required handlers",
+			Resource: &cfschema.Resource{
+				Handlers: map[string]*cfschema.Handler{
+					cfschema.HandlerTypeCreate: {},
+				},
+			},
+			Rule:              cfschema.LintRuleRequiredHandlers,
+			ExpectedLocations: []string{"/handlers", "/handlers"},
+		},
+		{
+			TestDescription: "required handlers no handlers",
+			Resource:        &cfschema.Resource{},
+			Rule:            cfschema.LintRuleRequiredHandlers,
+		},
+		{
+			TestDescription: "tag property exists",
+			Resource: &cfschema.Resource{
+				Tagging: &cfschema.Tagging{
+					TagProperty: testPropertyJsonPointer("/properties/Tags"),
+				},
+			},
+			Rule:     cfschema.LintRuleTagPropertyExists,
+			Expected: []cfschema.PropertyJsonPointer{"/properties/Tags"},
+		},
+		{
+			TestDescription: "additional properties false",
+			Resource: &cfschema.Resource{
+				Definitions: map[string]*cfschema.Property{
+					"Closed": {
+						AdditionalProperties: new(bool),
+						Properties: map[string]*cfschema.Property{
+							"Open": {
+								Properties: map[string]*cfschema.Property{
+									"Name": {Type: testType(cfschema.PropertyTypeString)},
+								},
+							},
+						},
+					},
+				},
+			},
+			Rule:              cfschema.LintRuleAdditionalPropertiesFalse,
+			ExpectedLocations: []string{"/additionalProperties", "/definitions/Closed/properties/Open"},
+		},
+		{
+			TestDescription: "additional properties false nested",
+			Resource: &cfschema.Resource{
+				AdditionalProperties: new(bool),
+				Properties: map[string]*cfschema.Property{
+					"Config": {
+						AdditionalProperties: new(bool),
+						Properties: map[string]*cfschema.Property{
+							"Rules/Items": {
+								Type: testType(cfschema.PropertyTypeArray),
+								Items: &cfschema.Property{
+									Properties: map[string]*cfschema.Property{
+										"Name": {Type: testType(cfschema.PropertyTypeString)},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Rule:              cfschema.LintRuleAdditionalPropertiesFalse,
+			Expected:          []cfschema.PropertyJsonPointer{"/properties/Config/Rules~1Items/*"},
+			ExpectedLocations: []string{"/properties/Config/properties/Rules~1Items/items"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			var rule cfschema.LintRule
+
+			for _, r := range cfschema.DefaultLintRules() {
+				if r.ID() == testCase.Rule {
+					rule = r
+				}
+			}
+
+			if rule == nil {
+				t.Fatalf("rule (%s) not found", testCase.Rule)
+			}
+
+			var actual []cfschema.PropertyJsonPointer
+			var actualLocations []string
+
+			for _, result := range testCase.Resource.Lint(rule) {
+				if result.Pointer != "" {
+					actual = append(actual, result.Pointer)
+				}
+
+				if result.Location != "" {
+					actualLocations = append(actualLocations, result.Location)
+				}
+			}
+
+			if expected := testCase.Expected; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("expected (%v), got: %v", expected, actual)
+			}
+
+			if expected := testCase.ExpectedLocations; !reflect.DeepEqual(actualLocations, expected) {
+				t.Errorf("expected locations (%v), got: %v", expected, actualLocations)
+			}
+		})
+	}
+}
+
+func TestDefaultLintRules_Resource(t *testing.T) {
+	resource := loadAndValidateResourceSchema(t, "provider.definition.schema.v1.json", "initech.tps.report.v1.json")
+
+	results := resource.Lint()
+
+	if results.HasErrors() {
+		t.Errorf("unexpected errors:\n%s", results)
+	}
+}
+
+func testPropertyJsonPointer(p string) *cfschema.PropertyJsonPointer {
+	result := cfschema.PropertyJsonPointer(p)
+
+	return &result
+}
@@ -0,0 +1,76 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestResourceLint(t *testing.T) {
+	customRule := cfschema.NewLintRule("description-required", cfschema.LintSeverityWarning, "resource description must be set", func(r *cfschema.Resource) cfschema.LintResults {
+		if r.Description == nil {
+			return cfschema.LintResults{{Message: "description not set"}}
+		}
+
+		return nil
+	})
+
+	testCases := []struct {
+		TestDescription string
+		Resource        *cfschema.Resource
+		Rules           []cfschema.LintRule
+		ExpectErrors    bool
+		ExpectedResults []string
+	}{
+		{
+			TestDescription: "nil resource",
+		},
+		{
+			TestDescription: "custom rule",
+			Resource:        &cfschema.Resource{},
+			Rules:           []cfschema.LintRule{customRule},
+			ExpectedResults: []string{"warning [description-required]: description not set"},
+		},
+		{
+			TestDescription: "custom rule passes",
+			Resource: &cfschema.Resource{
+				Description: new(string),
+			},
+			Rules: []cfschema.LintRule{customRule},
+		},
+		{
+			TestDescription: "default rules",
+			Resource: &cfschema.Resource{
+				AdditionalProperties: new(bool),
+				PrimaryIdentifier:    cfschema.PropertyJsonPointers{"/properties/Id"},
+			},
+			ExpectErrors:    true,
+			ExpectedResults: []string{"error [primary-identifier-exists] /properties/Id: primaryIdentifier property not found", "warning [primary-identifier-read-only-or-create-only] /properties/Id: primaryIdentifier property is neither in readOnlyProperties nor createOnlyProperties"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			results := testCase.Resource.Lint(testCase.Rules...)
+
+			if actual, expected := results.HasErrors(), testCase.ExpectErrors; actual != expected {
+				t.Errorf("expected errors (%t), got: %t", expected, actual)
+			}
+
+			if actual, expected := len(results), len(testCase.ExpectedResults); actual != expected {
+				t.Fatalf("expected %d results, got %d:\n%s", expected, actual, results)
+			}
+
+			for i, result := range results {
+				if actual, expected := result.String(), testCase.ExpectedResults[i]; actual != expected {
+					t.Errorf("expected result (%s), got: %s", expected, actual)
+				}
+			}
+		})
+	}
+}
@@ -44,33 +44,57 @@ const (
 
 // Property represents the CloudFormation Resource Schema customization for Definitions and Properties.
 type Property struct {
-	AdditionalProperties *bool                    `json:"additionalProperties,omitempty"`
-	AllOf                []*PropertySubschema     `json:"allOf,omitempty"`
-	AnyOf                []*PropertySubschema     `json:"anyOf,omitempty"`
-	ArrayType            *string                  `json:"arrayType,omitempty"`
-	Comment              *string                  `json:"$comment,omitempty"`
-	Default              interface{}              `json:"default,omitempty"`
-	Description          *string                  `json:"description,omitempty"`
-	Enum                 []interface{}            `json:"enum,omitempty"`
-	Examples             []interface{}            `json:"examples,omitempty"`
-	Format               *string                  `json:"format,omitempty"`
-	InsertionOrder       *bool                    `json:"insertionOrder,omitempty"`
-	Items                *Property                `json:"items,omitempty"`
-	Maximum              *json.Number             `json:"maximum,omitempty"`
-	MaxItems             *int                     `json:"maxItems,omitempty"`
-	MaxLength            *int                     `json:"maxLength,omitempty"`
-	Minimum              *json.Number             `json:"minimum,omitempty"`
-	MinItems             *int                     `json:"minItems,omitempty"`
-	MinLength            *int                     `json:"minLength,omitempty"`
-	OneOf                []*PropertySubschema     `json:"oneOf,omitempty"`
-	Pattern              *string                  `json:"pattern,omitempty"`
-	PatternProperties    map[string]*Property     `json:"patternProperties,omitempty"`
-	Properties           map[string]*Property     `json:"properties,omitempty"`
-	Ref                  *Reference               `json:"$ref,omitempty"`
-	RelationshipRef      *PropertyRelationshipRef `json:"relationshipRef,omitempty"`
-	Required             []string                 `json:"required,omitempty"`
-	Type                 *Type                    `json:"type,omitempty"`
-	UniqueItems          *bool                    `json:"uniqueItems,omitempty"`
+	AdditionalProperties   *bool                          `json:"additionalProperties,omitempty"`
+	AllOf                  []*PropertySubschema           `json:"allOf,omitempty"`
+	AnyOf                  []*PropertySubschema           `json:"anyOf,omitempty"`
+	ArrayType              *string                        `json:"arrayType,omitempty"`
+	Comment                *string                        `json:"$comment,omitempty"`
+	Const                  interface{}                    `json:"const,omitempty"`
+	Contains               *Property                      `json:"contains,omitempty"`
+	Default                interface{}                    `json:"default,omitempty"`
+	Dependencies           map[string]*PropertyDependency `json:"dependencies,omitempty"`
+	Description            *string                        `json:"description,omitempty"`
+	EmptyValues            Extensions                     `json:"-"`
+	Enum                   []interface{}                  `json:"enum,omitempty"`
+	Examples               []interface{}                  `json:"examples,omitempty"`
+	ExclusiveMaximum       *json.Number                   `json:"exclusiveMaximum,omitempty"`
+	ExclusiveMinimum       *json.Number                   `json:"exclusiveMinimum,omitempty"`
+	Extensions             Extensions                     `json:"-"`
+	Format                 *string                        `json:"format,omitempty"`
+	InsertionOrder         *bool                          `json:"insertionOrder,omitempty"`
+	Items                  *Property                      `json:"items,omitempty"`
+	Maximum                *json.Number                   `json:"maximum,omitempty"`
+	MaxItems               *int                           `json:"maxItems,omitempty"`
+	MaxLength              *int                           `json:"maxLength,omitempty"`
+	MaxProperties          *int                           `json:"maxProperties,omitempty"`
+	Minimum                *json.Number                   `json:"minimum,omitempty"`
+	MinItems               *int                           `json:"minItems,omitempty"`
+	MinLength              *int                           `json:"minLength,omitempty"`
+	MinProperties          *int                           `json:"minProperties,omitempty"`
+	MultipleOf             *json.Number                   `json:"multipleOf,omitempty"`
+	Not                    *Property                      `json:"not,omitempty"`
+	OneOf                  []*PropertySubschema           `json:"oneOf,omitempty"`
+	Pattern                *string                        `json:"pattern,omitempty"`
+	PatternProperties      map[string]*Property           `json:"patternProperties,omitempty"`
+	PatternPropertiesOrder []string                       `json:"-"`
+	Properties             map[string]*Property           `json:"properties,omitempty"`
+	PropertiesOrder        []string                       `json:"-"`
+	PropertyNames          *Property                      `json:"propertyNames,omitempty"`
+	RecursiveRef           *Reference                     `json:"-"`
+	Ref                    *Reference                     `json:"$ref,omitempty"`
+	RelationshipRef        *PropertyRelationshipRef       `json:"relationshipRef,omitempty"`
+	Required               []string                       `json:"required,omitempty"`
+	ResolvedRefs           []Reference                    `json:"-"`
+	SourcePosition         *SourcePosition                `json:"-"`
+	Title                  *string                        `json:"title,omitempty"`
+	Type                   *Type                          `json:"type,omitempty"`
+	UniqueItems            *bool                          `json:"uniqueItems,omitempty"`
+	UnwrappedOneOf         []*PropertySubschema           `json:"-"`
+}
+
+// Clone returns a deep copy of the Property that shares no values with the original.
+func (p *Property) Clone() *Property {
+	return cloneProperty(p, nil)
 }
 
 // String returns a string representation of Property.
@@ -97,3 +121,49 @@ func (p *Property) IsRequired(name string) bool {
 
 	return false
 }
+
+// MarshalJSON is a custom JSON handler for Property that preserves Extensions.
+// A RecursiveRef is written as $ref, so that recursive properties of an expanded Resource are not empty.
+func (p Property) MarshalJSON() ([]byte, error) {
+	type property Property
+
+	if p.Ref == nil && p.RecursiveRef != nil {
+		p.Ref = p.RecursiveRef
+	}
+
+	b, err := json.Marshal(property(p))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalExtensions(b, p.Extensions, p.EmptyValues)
+}
+
+// UnmarshalJSON is a custom JSON handler for Property that captures Extensions
+// and the declared order of PatternProperties and Properties.
+func (p *Property) UnmarshalJSON(b []byte) error {
+	type property Property
+
+	var v property
+
+	extensions, emptyValues, err := unmarshalExtensions(b, &v)
+
+	if err != nil {
+		return err
+	}
+
+	orders, err := unmarshalKeyOrder(b, "patternProperties", "properties")
+
+	if err != nil {
+		return err
+	}
+
+	*p = Property(v)
+	p.EmptyValues = emptyValues
+	p.Extensions = extensions
+	p.PatternPropertiesOrder = orders[0]
+	p.PropertiesOrder = orders[1]
+
+	return nil
+}
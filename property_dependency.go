@@ -0,0 +1,68 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// PropertyDependency represents a JSON Schema dependencies value.
+//
+// A dependency is either a list of property names that are required when the
+// dependent property is present (property dependency) or a schema that must
+// also be satisfied when the dependent property is present (schema dependency).
+type PropertyDependency struct {
+	Properties []string
+	Schema     *Property
+}
+
+// IsSchema returns true if the dependency is a schema dependency.
+func (d *PropertyDependency) IsSchema() bool {
+	if d == nil {
+		return false
+	}
+
+	return d.Schema != nil
+}
+
+// MarshalJSON is a custom JSON handler for PropertyDependency.
+func (d PropertyDependency) MarshalJSON() ([]byte, error) {
+	if d.Schema != nil {
+		return json.Marshal(d.Schema)
+	}
+
+	if d.Properties == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(d.Properties)
+}
+
+// UnmarshalJSON is a custom JSON handler for PropertyDependency.
+func (d *PropertyDependency) UnmarshalJSON(b []byte) error {
+	switch b := bytes.TrimSpace(b); {
+	case len(b) > 0 && b[0] == '[':
+		var properties []string
+
+		if err := json.Unmarshal(b, &properties); err != nil {
+			return err
+		}
+
+		*d = PropertyDependency{Properties: properties}
+	case len(b) > 0 && b[0] == '{':
+		var schema Property
+
+		if err := json.Unmarshal(b, &schema); err != nil {
+			return err
+		}
+
+		*d = PropertyDependency{Schema: &schema}
+	default:
+		return fmt.Errorf("unsupported dependency value: %s", b)
+	}
+
+	return nil
+}
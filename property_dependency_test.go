@@ -0,0 +1,85 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestPropertyDependency(t *testing.T) {
+	testCases := []struct {
+		TestDescription    string
+		Document           string
+		ExpectError        bool
+		ExpectSchema       bool
+		ExpectedProperties []string
+	}{
+		{
+			TestDescription:    "property dependency",
+			Document:           `["Key", "Value"]`,
+			ExpectedProperties: []string{"Key", "Value"},
+		},
+		{
+			TestDescription:    "empty property dependency",
+			Document:           `[]`,
+			ExpectedProperties: []string{},
+		},
+		{
+			TestDescription: "schema dependency",
+			Document:        `{"required": ["Key"]}`,
+			ExpectSchema:    true,
+		},
+		{
+			TestDescription: "invalid",
+			Document:        `"Key"`,
+			ExpectError:     true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			var dependency cfschema.PropertyDependency
+
+			err := json.Unmarshal([]byte(testCase.Document), &dependency)
+
+			if err != nil && !testCase.ExpectError {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err == nil && testCase.ExpectError {
+				t.Fatal("expected error, got none")
+			}
+
+			if err != nil {
+				return
+			}
+
+			if actual, expected := dependency.IsSchema(), testCase.ExpectSchema; actual != expected {
+				t.Errorf("expected schema dependency (%t), got: %t", expected, actual)
+			}
+
+			if !testCase.ExpectSchema {
+				if actual, expected := dependency.Properties, testCase.ExpectedProperties; !reflect.DeepEqual(actual, expected) {
+					t.Errorf("expected (%#v), got: %#v", expected, actual)
+				}
+			}
+
+			b, err := json.Marshal(dependency)
+
+			if err != nil {
+				t.Fatalf("unexpected marshal error: %s", err)
+			}
+
+			if actual, expected := string(b), compactJSON(t, testCase.Document); actual != expected {
+				t.Errorf("expected (%s), got: %s", expected, actual)
+			}
+		})
+	}
+}
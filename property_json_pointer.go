@@ -9,11 +9,21 @@ const (
 	JsonPointerReferenceTokenSeparator  = "/"
 	PropertiesJsonPointerReferenceToken = "properties"
 	PropertiesJsonPointerPrefix         = JsonPointerReferenceTokenSeparator + PropertiesJsonPointerReferenceToken
+
+	// PropertyJsonPointerWildcard is the reference token matching every array element or pattern property.
+	PropertyJsonPointerWildcard = "*"
 )
 
-// PropertyJsonPointer is a simplistic RFC 6901 handler for properties JSON Pointers.
+// PropertyJsonPointer is an RFC 6901 handler for properties JSON Pointers.
 type PropertyJsonPointer string
 
+// NewPropertyJsonPointer returns a PropertyJsonPointer from unescaped property path parts.
+//
+// This automatically handles adding the /properties prefix.
+func NewPropertyJsonPointer(path ...string) PropertyJsonPointer {
+	return PropertyJsonPointer(PropertiesJsonPointerPrefix + joinJsonPointer(path))
+}
+
 // EqualsPath returns true if all path parts match.
 //
 // This automatically handles stripping the /properties prefix.
@@ -50,7 +60,7 @@ func (p *PropertyJsonPointer) EqualsStringPath(path string) bool {
 	return trimmedPath == path
 }
 
-// Path returns the path parts.
+// Path returns the unescaped path parts.
 //
 // This automatically handles stripping the /properties path part.
 func (p *PropertyJsonPointer) Path() []string {
@@ -60,6 +70,10 @@ func (p *PropertyJsonPointer) Path() []string {
 
 	pathParts := strings.Split(strings.TrimPrefix(string(*p), PropertiesJsonPointerPrefix+JsonPointerReferenceTokenSeparator), JsonPointerReferenceTokenSeparator)
 
+	for i, pathPart := range pathParts {
+		pathParts[i] = UnescapeJsonPointerReferenceToken(pathPart)
+	}
+
 	return pathParts
 }
 
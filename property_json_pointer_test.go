@@ -203,6 +203,11 @@ func TestPropertyJsonPointerPath(t *testing.T) {
 			PropertyJsonPointer: "/properties/parent/nested",
 			Expected:            []string{"parent", "nested"},
 		},
+		{
+			TestDescription:     "escaped",
+			PropertyJsonPointer: "/properties/a~1b/m~0n",
+			Expected:            []string{"a/b", "m~n"},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -215,3 +220,43 @@ func TestPropertyJsonPointerPath(t *testing.T) {
 		})
 	}
 }
+
+func TestNewPropertyJsonPointer(t *testing.T) {
+	testCases := []struct {
+		TestDescription string
+		Path            []string
+		Expected        cfschema.PropertyJsonPointer
+	}{
+		{
+			TestDescription: "one level",
+			Path:            []string{"test"},
+			Expected:        "/properties/test",
+		},
+		{
+			TestDescription: "multi level",
+			Path:            []string{"parent", "*", "nested"},
+			Expected:        "/properties/parent/*/nested",
+		},
+		{
+			TestDescription: "escaped",
+			Path:            []string{"a/b", "m~n"},
+			Expected:        "/properties/a~1b/m~0n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			actual := cfschema.NewPropertyJsonPointer(testCase.Path...)
+
+			if expected := testCase.Expected; actual != expected {
+				t.Fatalf("expected (%s), got: %s", expected, actual)
+			}
+
+			if !actual.EqualsPath(testCase.Path) {
+				t.Errorf("expected (%s) to equal path (%#v)", actual, testCase.Path)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// OrderedDefinitionNames returns the names of the Definitions in the order they were declared in the
+// schema document. Any definitions not declared in the document, e.g. added after unmarshalling, follow in name order.
+func (r *Resource) OrderedDefinitionNames() []string {
+	if r == nil {
+		return nil
+	}
+
+	return orderedPropertyNames(r.Definitions, r.DefinitionsOrder)
+}
+
+// OrderedPropertyNames returns the names of the Properties in the order they were declared in the
+// schema document. Any properties not declared in the document, e.g. added after unmarshalling, follow in name order.
+func (r *Resource) OrderedPropertyNames() []string {
+	if r == nil {
+		return nil
+	}
+
+	return orderedPropertyNames(r.Properties, r.PropertiesOrder)
+}
+
+// OrderedPatternPropertyNames returns the patterns of the PatternProperties in the order they were declared in the
+// schema document. Any patterns not declared in the document follow in name order.
+func (p *Property) OrderedPatternPropertyNames() []string {
+	if p == nil {
+		return nil
+	}
+
+	return orderedPropertyNames(p.PatternProperties, p.PatternPropertiesOrder)
+}
+
+// OrderedPropertyNames returns the names of the Properties in the order they were declared in the
+// schema document. Any properties not declared in the document follow in name order.
+func (p *Property) OrderedPropertyNames() []string {
+	if p == nil {
+		return nil
+	}
+
+	return orderedPropertyNames(p.Properties, p.PropertiesOrder)
+}
+
+// OrderedPropertyNames returns the names of the Properties in the order they were declared in the
+// schema document. Any properties not declared in the document follow in name order.
+func (s *PropertySubschema) OrderedPropertyNames() []string {
+	if s == nil {
+		return nil
+	}
+
+	return orderedPropertyNames(s.Properties, s.PropertiesOrder)
+}
+
+// orderedPropertyNames returns the names of a name-to-property map, those in order first.
+// Names in order but no longer in the map are omitted.
+func orderedPropertyNames(properties map[string]*Property, order []string) []string {
+	if len(properties) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(properties))
+	seen := make(map[string]struct{}, len(properties))
+
+	for _, name := range order {
+		if _, ok := properties[name]; !ok {
+			continue
+		}
+
+		if _, ok := seen[name]; ok {
+			continue
+		}
+
+		names = append(names, name)
+		seen[name] = struct{}{}
+	}
+
+	var remaining []string
+
+	for name := range properties {
+		if _, ok := seen[name]; !ok {
+			remaining = append(remaining, name)
+		}
+	}
+
+	sort.Strings(remaining)
+
+	return append(names, remaining...)
+}
+
+// unmarshalKeyOrder returns the keys, in document order, of each named object member of the JSON object.
+// A nil list is returned for members which are missing or not objects.
+func unmarshalKeyOrder(b []byte, names ...string) ([][]string, error) {
+	var raw map[string]json.RawMessage
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+
+	orders := make([][]string, len(names))
+
+	for i, name := range names {
+		value, ok := raw[name]
+
+		if !ok {
+			continue
+		}
+
+		keys, err := objectKeys(value)
+
+		if err != nil {
+			return nil, fmt.Errorf("reading %s key order: %w", name, err)
+		}
+
+		orders[i] = keys
+	}
+
+	return orders, nil
+}
+
+// objectKeys returns the keys of a JSON object in document order, or nil if the value is not an object.
+func objectKeys(b []byte) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+
+	token, err := dec.Token()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, nil
+	}
+
+	var keys []string
+
+	for dec.More() {
+		token, err := dec.Token()
+
+		if err != nil {
+			return nil, err
+		}
+
+		key, ok := token.(string)
+
+		if !ok {
+			return nil, fmt.Errorf("unexpected object key: %v", token)
+		}
+
+		var value json.RawMessage
+
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
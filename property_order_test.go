@@ -0,0 +1,158 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestResourceOrderedPropertyNames(t *testing.T) {
+	schema := `{
+		"typeName": "Initech::TPS::Report",
+		"definitions": {
+			"Tag": {"type": "object", "properties": {"Value": {"type": "string"}, "Key": {"type": "string"}}},
+			"Memo": {"type": "object", "patternProperties": {"^b": {"type": "string"}, "^a": {"type": "string"}}}
+		},
+		"properties": {
+			"Title": {"type": "string"},
+			"Memo": {"$ref": "#/definitions/Memo"},
+			"Tags": {"type": "array", "items": {"$ref": "#/definitions/Tag"}},
+			"Code": {"type": "string"}
+		}
+	}`
+
+	testCases := []struct {
+		TestDescription string
+		Modify          func(*cfschema.Resource) error
+		Definitions     []string
+		Properties      []string
+		TagProperties   []string
+		MemoPatterns    []string
+	}{
+		{
+			TestDescription: "unmarshalled",
+			Definitions:     []string{"Tag", "Memo"},
+			Properties:      []string{"Title", "Memo", "Tags", "Code"},
+			TagProperties:   []string{"Value", "Key"},
+			MemoPatterns:    []string{"^b", "^a"},
+		},
+		{
+			TestDescription: "expanded",
+			Modify: func(r *cfschema.Resource) error {
+				return r.Expand()
+			},
+			Definitions:   []string{"Tag", "Memo"},
+			Properties:    []string{"Title", "Memo", "Tags", "Code"},
+			TagProperties: []string{"Value", "Key"},
+			MemoPatterns:  []string{"^b", "^a"},
+		},
+		{
+			TestDescription: "modified",
+			Modify: func(r *cfschema.Resource) error {
+				delete(r.Properties, "Memo")
+				r.Properties["Author"] = &cfschema.Property{}
+				r.Properties["Abstract"] = &cfschema.Property{}
+
+				return nil
+			},
+			Definitions:   []string{"Tag", "Memo"},
+			Properties:    []string{"Title", "Tags", "Code", "Abstract", "Author"},
+			TagProperties: []string{"Value", "Key"},
+			MemoPatterns:  []string{"^b", "^a"},
+		},
+		{
+			TestDescription: "constructed",
+			Modify: func(r *cfschema.Resource) error {
+				*r = cfschema.Resource{
+					Definitions: map[string]*cfschema.Property{
+						"Tag":  {Properties: map[string]*cfschema.Property{"Value": {}, "Key": {}}},
+						"Memo": {},
+					},
+					Properties: map[string]*cfschema.Property{"Title": {}, "Code": {}},
+				}
+
+				return nil
+			},
+			Definitions:   []string{"Memo", "Tag"},
+			Properties:    []string{"Code", "Title"},
+			TagProperties: []string{"Key", "Value"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			var resource cfschema.Resource
+
+			if err := json.Unmarshal([]byte(schema), &resource); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if testCase.Modify != nil {
+				if err := testCase.Modify(&resource); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+
+			for _, r := range []*cfschema.Resource{&resource, resource.Clone()} {
+				if actual, expected := r.OrderedDefinitionNames(), testCase.Definitions; !reflect.DeepEqual(actual, expected) {
+					t.Errorf("expected definitions %q, got: %q", expected, actual)
+				}
+
+				if actual, expected := r.OrderedPropertyNames(), testCase.Properties; !reflect.DeepEqual(actual, expected) {
+					t.Errorf("expected properties %q, got: %q", expected, actual)
+				}
+
+				if actual, expected := r.Definitions["Tag"].OrderedPropertyNames(), testCase.TagProperties; !reflect.DeepEqual(actual, expected) {
+					t.Errorf("expected Tag properties %q, got: %q", expected, actual)
+				}
+
+				if actual, expected := r.Definitions["Memo"].OrderedPatternPropertyNames(), testCase.MemoPatterns; !reflect.DeepEqual(actual, expected) {
+					t.Errorf("expected Memo pattern properties %q, got: %q", expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestPropertyOrderedPropertyNames_UnwrappedOneOf(t *testing.T) {
+	var resource cfschema.Resource
+
+	if err := json.Unmarshal([]byte(`{
+		"typeName": "Initech::TPS::Report",
+		"definitions": {
+			"Source": {
+				"type": "object",
+				"oneOf": [
+					{"properties": {"Zeta": {"type": "string"}, "Alpha": {"type": "string"}}, "required": ["Zeta"]},
+					{"properties": {"Mid": {"type": "string"}, "Alpha": {"type": "string"}}, "required": ["Mid"]}
+				]
+			}
+		},
+		"properties": {
+			"Source": {"$ref": "#/definitions/Source"}
+		}
+	}`), &resource); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if actual, expected := resource.Definitions["Source"].OneOf[1].OrderedPropertyNames(), []string{"Mid", "Alpha"}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected oneOf properties %q, got: %q", expected, actual)
+	}
+
+	if err := resource.Expand(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, r := range []*cfschema.Resource{&resource, resource.Clone()} {
+		if actual, expected := r.Properties["Source"].OrderedPropertyNames(), []string{"Zeta", "Alpha", "Mid"}; !reflect.DeepEqual(actual, expected) {
+			t.Errorf("expected unwrapped properties %q, got: %q", expected, actual)
+		}
+	}
+}
@@ -3,10 +3,62 @@
 
 package cfschema
 
+import (
+	"encoding/json"
+)
+
 type PropertySubschema struct {
-	AllOf      []*PropertySubschema `json:"allOf,omitempty"`
-	AnyOf      []*PropertySubschema `json:"anyOf,omitempty"`
-	OneOf      []*PropertySubschema `json:"oneOf,omitempty"`
-	Properties map[string]*Property `json:"properties,omitempty"`
-	Required   []string             `json:"required,omitempty"`
+	AllOf           []*PropertySubschema `json:"allOf,omitempty"`
+	AnyOf           []*PropertySubschema `json:"anyOf,omitempty"`
+	EmptyValues     Extensions           `json:"-"`
+	Extensions      Extensions           `json:"-"`
+	OneOf           []*PropertySubschema `json:"oneOf,omitempty"`
+	Properties      map[string]*Property `json:"properties,omitempty"`
+	PropertiesOrder []string             `json:"-"`
+	Required        []string             `json:"required,omitempty"`
+}
+
+// Clone returns a deep copy of the PropertySubschema that shares no values with the original.
+func (s *PropertySubschema) Clone() *PropertySubschema {
+	return clonePropertySubschema(s, nil)
+}
+
+// MarshalJSON is a custom JSON handler for PropertySubschema that preserves Extensions.
+func (s PropertySubschema) MarshalJSON() ([]byte, error) {
+	type propertySubschema PropertySubschema
+
+	b, err := json.Marshal(propertySubschema(s))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalExtensions(b, s.Extensions, s.EmptyValues)
+}
+
+// UnmarshalJSON is a custom JSON handler for PropertySubschema that captures Extensions
+// and the declared order of Properties.
+func (s *PropertySubschema) UnmarshalJSON(b []byte) error {
+	type propertySubschema PropertySubschema
+
+	var v propertySubschema
+
+	extensions, emptyValues, err := unmarshalExtensions(b, &v)
+
+	if err != nil {
+		return err
+	}
+
+	orders, err := unmarshalKeyOrder(b, "properties")
+
+	if err != nil {
+		return err
+	}
+
+	*s = PropertySubschema(v)
+	s.EmptyValues = emptyValues
+	s.Extensions = extensions
+	s.PropertiesOrder = orders[0]
+
+	return nil
 }
@@ -4,6 +4,8 @@
 package cfschema_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"path/filepath"
 	"testing"
 
@@ -50,6 +52,68 @@ func TestProperty_IsRequired(t *testing.T) {
 	}
 }
 
+func TestProperty_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		TestDescription string
+		Document        string
+	}{
+		{
+			TestDescription: "numeric constraints",
+			Document:        `{"exclusiveMaximum":100,"exclusiveMinimum":0.5,"multipleOf":0.01,"type":"number"}`,
+		},
+		{
+			TestDescription: "object constraints",
+			Document:        `{"maxProperties":10,"minProperties":1,"propertyNames":{"pattern":"^[a-z]+$"},"type":"object"}`,
+		},
+		{
+			TestDescription: "const and not",
+			Document:        `{"const":"fixed","not":{"enum":["other"]},"title":"Fixed","type":"string"}`,
+		},
+		{
+			TestDescription: "contains",
+			Document:        `{"contains":{"type":"string"},"type":"array"}`,
+		},
+		{
+			TestDescription: "dependencies",
+			Document:        `{"dependencies":{"Key":["Value"],"Name":{"required":["Id"]}},"type":"object"}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			var property cfschema.Property
+
+			if err := json.Unmarshal([]byte(testCase.Document), &property); err != nil {
+				t.Fatalf("unexpected unmarshal error: %s", err)
+			}
+
+			b, err := json.Marshal(&property)
+
+			if err != nil {
+				t.Fatalf("unexpected marshal error: %s", err)
+			}
+
+			if actual, expected := string(b), compactJSON(t, testCase.Document); actual != expected {
+				t.Errorf("expected (%s), got: %s", expected, actual)
+			}
+		})
+	}
+}
+
+func compactJSON(t *testing.T, document string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	if err := json.Compact(&buf, []byte(document)); err != nil {
+		t.Fatalf("unexpected error compacting JSON: %s", err)
+	}
+
+	return buf.String()
+}
+
 func loadAndValidateResourceSchema(t *testing.T, metaSchemaPath, resourceSchemaPath string) *cfschema.Resource {
 	metaSchema, err := cfschema.NewMetaJsonSchemaPath(filepath.Join("testdata", metaSchemaPath))
 
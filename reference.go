@@ -5,28 +5,88 @@ package cfschema
 
 import (
 	"fmt"
+	"path"
 	"strings"
 )
 
 const (
 	ReferenceAnchor          = "#"
+	ReferenceFileScheme      = "file://"
 	ReferenceSeparator       = "/"
 	ReferenceTypeDefinitions = "definitions"
 	ReferenceTypeProperties  = "properties"
+	ReferenceTypeRemote      = "remote"
 )
 
 // Reference is an internal implementation for RFC 6901 JSON Pointer values.
 type Reference string
 
-// Field returns the JSON Pointer string path after the type.
+// NewReference returns a Reference from a type (e.g. definitions) and unescaped path parts.
+func NewReference(typ string, path ...string) Reference {
+	return Reference(ReferenceAnchor + joinJsonPointer(append([]string{typ}, path...)))
+}
+
+// Document returns the referenced document path, or the empty string for a Reference local to the resource schema.
+//
+// Any file:// scheme is removed and relative paths are cleaned, e.g. file://./common.json#/definitions/Tag
+// returns common.json. Other URLs are returned unmodified.
+func (r Reference) Document() string {
+	document, _ := r.split()
+
+	if document == "" || isReferenceURL(document) {
+		return document
+	}
+
+	return path.Clean(strings.TrimPrefix(document, ReferenceFileScheme))
+}
+
+// IsLocal returns true if the Reference is local to the resource schema.
+func (r Reference) IsLocal() bool {
+	return r.Document() == ""
+}
+
+// Field returns the unescaped JSON Pointer path part after the type.
 func (r Reference) Field() (string, error) {
-	referenceParts := strings.Split(strings.TrimPrefix(string(r), ReferenceAnchor), ReferenceSeparator)
+	path, err := r.Path()
+
+	if err != nil {
+		return "", err
+	}
+
+	return path[1], nil
+}
 
-	if got, expected := len(referenceParts), 3; got != expected {
-		return "", fmt.Errorf("invalid Reference (%s). Expected %d parts, got %d", r, expected, got)
+// Path returns all unescaped JSON Pointer path parts, starting with the type.
+//
+// At least a type and a field are required, e.g. #/definitions/Foo.
+// Deeper references, e.g. #/definitions/Foo/properties/Bar, are supported.
+func (r Reference) Path() ([]string, error) {
+	_, pointer := r.split()
+
+	if !strings.HasPrefix(pointer, ReferenceSeparator) {
+		return nil, fmt.Errorf("invalid Reference (%s). Expected %s prefix", r, ReferenceSeparator)
+	}
+
+	referenceParts := splitJsonPointer(pointer)
+
+	if got, expected := len(referenceParts), 2; got < expected || referenceParts[1] == "" {
+		return nil, fmt.Errorf("invalid Reference (%s). Expected at least %d non-empty parts, got %d", r, expected, got)
+	}
+
+	return referenceParts, nil
+}
+
+// split returns the document and JSON Pointer fragment parts of the Reference.
+func (r Reference) split() (string, string) {
+	if document, fragment, ok := strings.Cut(string(r), ReferenceAnchor); ok {
+		return document, fragment
+	}
+
+	if strings.HasPrefix(string(r), ReferenceSeparator) {
+		return "", string(r)
 	}
 
-	return referenceParts[2], nil
+	return string(r), ""
 }
 
 // String returns the string representation of a Reference.
@@ -38,11 +98,18 @@ func (r Reference) String() string {
 //
 // In CloudFormation Resources, this should be definitions or properties.
 func (r Reference) Type() (string, error) {
-	referenceParts := strings.Split(strings.TrimPrefix(string(r), ReferenceAnchor), ReferenceSeparator)
+	path, err := r.Path()
 
-	if got, expected := len(referenceParts), 3; got != expected {
-		return "", fmt.Errorf("invalid Reference (%s). Expected %d parts, got %d", r, expected, got)
+	if err != nil {
+		return "", err
 	}
 
-	return referenceParts[1], nil
+	return path[0], nil
+}
+
+// isReferenceURL returns true if the document is a URL other than a file:// URL.
+func isReferenceURL(document string) bool {
+	scheme, _, ok := strings.Cut(document, "://")
+
+	return ok && scheme+"://" != ReferenceFileScheme
 }
@@ -0,0 +1,65 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ReferenceLoader loads documents referenced by a Reference that is not local to the resource schema,
+// e.g. file://./common.definitions.json#/definitions/Tag.
+//
+// Document paths are slash-separated and relative to the loader root.
+type ReferenceLoader interface {
+	Load(path string) ([]byte, error)
+}
+
+// fsReferenceLoader loads referenced documents from a file system.
+type fsReferenceLoader struct {
+	fsys fs.FS
+}
+
+// NewFSReferenceLoader returns a ReferenceLoader that loads referenced documents from a file system, e.g. embed.FS.
+func NewFSReferenceLoader(fsys fs.FS) ReferenceLoader {
+	return &fsReferenceLoader{
+		fsys: fsys,
+	}
+}
+
+func (l *fsReferenceLoader) Load(path string) ([]byte, error) {
+	return fs.ReadFile(l.fsys, path)
+}
+
+// directoryReferenceLoader loads referenced documents from a local directory.
+type directoryReferenceLoader struct {
+	dir string
+}
+
+// NewDirectoryReferenceLoader returns a ReferenceLoader that loads referenced documents relative to a local directory.
+// Unlike file systems, paths may refer to parent directories.
+func NewDirectoryReferenceLoader(dir string) ReferenceLoader {
+	return &directoryReferenceLoader{
+		dir: dir,
+	}
+}
+
+func (l *directoryReferenceLoader) Load(path string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(l.dir, filepath.FromSlash(path)))
+}
+
+// MapReferenceLoader is a ReferenceLoader for in-memory documents keyed by path.
+type MapReferenceLoader map[string]string
+
+func (l MapReferenceLoader) Load(path string) ([]byte, error) {
+	document, ok := l[path]
+
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", path, fs.ErrNotExist)
+	}
+
+	return []byte(document), nil
+}
@@ -4,6 +4,7 @@
 package cfschema_test
 
 import (
+	"reflect"
 	"testing"
 
 	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
@@ -56,6 +57,21 @@ func TestReferenceField(t *testing.T) {
 			Reference:       cfschema.Reference("#/properties/test"),
 			Expected:        "test",
 		},
+		{
+			TestDescription: "nested",
+			Reference:       cfschema.Reference("#/definitions/test/properties/nested"),
+			Expected:        "test",
+		},
+		{
+			TestDescription: "escaped",
+			Reference:       cfschema.Reference("#/definitions/a~1b"),
+			Expected:        "a/b",
+		},
+		{
+			TestDescription: "empty field",
+			Reference:       cfschema.Reference("#/definitions/"),
+			ExpectError:     true,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -138,3 +154,110 @@ func TestReferenceType(t *testing.T) {
 		})
 	}
 }
+
+func TestReferencePath(t *testing.T) {
+	testCases := []struct {
+		TestDescription string
+		Reference       cfschema.Reference
+		ExpectError     bool
+		Expected        []string
+	}{
+		{
+			TestDescription: "empty",
+			Reference:       cfschema.Reference(""),
+			ExpectError:     true,
+		},
+		{
+			TestDescription: "no separator",
+			Reference:       cfschema.Reference("#definitions/test"),
+			ExpectError:     true,
+		},
+		{
+			TestDescription: "definition",
+			Reference:       cfschema.Reference("#/definitions/test"),
+			Expected:        []string{"definitions", "test"},
+		},
+		{
+			TestDescription: "nested",
+			Reference:       cfschema.Reference("#/definitions/test/properties/a~1b/items"),
+			Expected:        []string{"definitions", "test", "properties", "a/b", "items"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			actual, err := testCase.Reference.Path()
+
+			if err != nil && !testCase.ExpectError {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err == nil && testCase.ExpectError {
+				t.Fatal("expected error, got none")
+			}
+
+			if expected := testCase.Expected; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("expected (%#v), got: %#v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestNewReference(t *testing.T) {
+	if actual, expected := cfschema.NewReference(cfschema.ReferenceTypeDefinitions, "a/b", "properties", "m~n"), cfschema.Reference("#/definitions/a~1b/properties/m~0n"); actual != expected {
+		t.Errorf("expected (%s), got: %s", expected, actual)
+	}
+}
+
+func TestReferenceDocument(t *testing.T) {
+	testCases := []struct {
+		TestDescription string
+		Reference       cfschema.Reference
+		Expected        string
+	}{
+		{
+			TestDescription: "local",
+			Reference:       cfschema.Reference("#/definitions/test"),
+		},
+		{
+			TestDescription: "local without anchor",
+			Reference:       cfschema.Reference("/definitions/test"),
+		},
+		{
+			TestDescription: "file URL",
+			Reference:       cfschema.Reference("file://./common.json#/definitions/test"),
+			Expected:        "common.json",
+		},
+		{
+			TestDescription: "relative path",
+			Reference:       cfschema.Reference("shared/../common.json#/definitions/test"),
+			Expected:        "common.json",
+		},
+		{
+			TestDescription: "whole document",
+			Reference:       cfschema.Reference("common.json"),
+			Expected:        "common.json",
+		},
+		{
+			TestDescription: "URL",
+			Reference:       cfschema.Reference("https://example.com/schemas/common.json#/definitions/test"),
+			Expected:        "https://example.com/schemas/common.json",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			if actual, expected := testCase.Reference.Document(), testCase.Expected; actual != expected {
+				t.Errorf("expected (%s), got: %s", expected, actual)
+			}
+
+			if actual, expected := testCase.Reference.IsLocal(), testCase.Expected == ""; actual != expected {
+				t.Errorf("expected local (%t), got: %t", expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+// Remote represents an inlined remote schema.
+//
+// Remote schemas are reserved for CloudFormation use and are keyed by schema
+// name (e.g. schema0) in the Resource remote namespace.
+type Remote struct {
+	Comment     *string              `json:"$comment,omitempty"`
+	Definitions map[string]*Property `json:"definitions,omitempty"`
+	Properties  map[string]*Property `json:"properties,omitempty"`
+}
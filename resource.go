@@ -4,6 +4,7 @@
 package cfschema
 
 import (
+	"encoding/json"
 	"fmt"
 )
 
@@ -15,24 +16,45 @@ type Resource struct {
 	ConditionalCreateOnlyProperties PropertyJsonPointers   `json:"conditionalCreateOnlyProperties,omitempty"`
 	CreateOnlyProperties            PropertyJsonPointers   `json:"createOnlyProperties,omitempty"`
 	Definitions                     map[string]*Property   `json:"definitions,omitempty"`
+	DefinitionsOrder                []string               `json:"-"`
 	DeprecatedProperties            PropertyJsonPointers   `json:"deprecatedProperties,omitempty"`
 	Description                     *string                `json:"description,omitempty"`
+	DocumentationURL                *string                `json:"documentationUrl,omitempty"`
+	EmptyValues                     Extensions             `json:"-"`
+	Extensions                      Extensions             `json:"-"`
 	Handlers                        map[string]*Handler    `json:"handlers,omitempty"`
 	NonPublicDefinitions            PropertyJsonPointers   `json:"nonPublicDefinitions,omitempty"`
 	NonPublicProperties             PropertyJsonPointers   `json:"nonPublicProperties,omitempty"`
 	OneOf                           []*PropertySubschema   `json:"oneOf,omitempty"`
 	PrimaryIdentifier               PropertyJsonPointers   `json:"primaryIdentifier,omitempty"`
 	Properties                      map[string]*Property   `json:"properties,omitempty"`
+	PropertiesOrder                 []string               `json:"-"`
 	PropertyTransform               PropertyTransform      `json:"propertyTransform,omitempty"`
 	ReadOnlyProperties              PropertyJsonPointers   `json:"readOnlyProperties,omitempty"`
+	Remote                          map[string]*Remote     `json:"remote,omitempty"`
 	ReplacementStrategy             *string                `json:"replacementStrategy,omitempty"`
 	Required                        []string               `json:"required,omitempty"`
 	ResourceLink                    *ResourceLink          `json:"resourceLink,omitempty"`
+	Schema                          *string                `json:"$schema,omitempty"`
 	SourceURL                       *string                `json:"sourceUrl,omitempty"`
 	Taggable                        *bool                  `json:"taggable,omitempty"`
 	Tagging                         *Tagging               `json:"tagging,omitempty"`
+	Title                           *string                `json:"title,omitempty"`
+	TypeConfiguration               *TypeConfiguration     `json:"typeConfiguration,omitempty"`
 	TypeName                        *string                `json:"typeName,omitempty"`
 	WriteOnlyProperties             PropertyJsonPointers   `json:"writeOnlyProperties,omitempty"`
+
+	expandState        *expandState
+	referenceBase      string
+	referenceDocuments *referenceDocumentCache
+	referenceLoader    ReferenceLoader
+	sourcePositions    map[string]*SourcePosition
+}
+
+// Clone returns a deep copy of the Resource that shares no values with the original.
+// Any ReferenceLoader configured on the Resource is also used by the copy.
+func (r *Resource) Clone() *Resource {
+	return cloneResource(r)
 }
 
 func (r *Resource) IsCreateOnlyPropertyPath(path string) bool {
@@ -64,17 +86,31 @@ func (r *Resource) IsRequired(name string) bool {
 }
 
 // ResolveReference resolves a Reference (JSON Pointer) into a Property.
+//
+// References may point into nested properties, e.g. #/definitions/Foo/properties/Bar,
+// traversing properties, patternProperties and items. Any References in intermediate
+// properties are followed; the target Property is returned as-is.
+//
+// References to inlined remote schemas, e.g. #/remote/schema0/definitions/Foo, are supported.
+// References to other documents, e.g. file://./common.json#/definitions/Foo, are loaded with the
+// ReferenceLoader and return a copy of the target Property with any References rebased to that document.
 func (r *Resource) ResolveReference(ref Reference) (*Property, error) {
 	if r == nil {
 		return nil, nil
 	}
 
-	typ, err := ref.Type()
+	if !ref.IsLocal() {
+		return r.resolveDocumentReference(ref)
+	}
+
+	path, err := ref.Path()
 
 	if err != nil {
 		return nil, err
 	}
 
+	typ, field := path[0], path[1]
+
 	var properties map[string]*Property
 
 	switch typ {
@@ -82,20 +118,101 @@ func (r *Resource) ResolveReference(ref Reference) (*Property, error) {
 		properties = r.Definitions
 	case ReferenceTypeProperties:
 		properties = r.Properties
+	case ReferenceTypeRemote:
+		return r.resolveRemoteReference(ref, path)
 	default:
 		return nil, fmt.Errorf("unexpected Reference type: %s", typ)
 	}
 
-	field, err := ref.Field()
+	property, ok := properties[field]
+	if !ok || property == nil {
+		return nil, fmt.Errorf("%s/%s not found", typ, field)
+	}
+
+	return r.resolvePropertyPath(ref, property, path[2:])
+}
+
+// resolvePropertyPath resolves the remaining unescaped Reference path parts relative to a Property.
+func (r *Resource) resolvePropertyPath(ref Reference, property *Property, path []string) (*Property, error) {
+	for len(path) > 0 {
+		if property.Ref != nil {
+			resolution := r.resolvedProperty(property)
+
+			if resolution == nil {
+				return nil, fmt.Errorf("resolving %s: unable to resolve %s", ref, property.Ref)
+			}
+
+			property = resolution
+		}
+
+		var next *Property
+
+		switch token := path[0]; token {
+		case "items":
+			next, path = property.Items, path[1:]
+		case "patternProperties", "properties":
+			if len(path) < 2 {
+				return nil, fmt.Errorf("resolving %s: missing %s name", ref, token)
+			}
+
+			if token == "properties" {
+				next = property.Properties[path[1]]
+			} else {
+				next = property.PatternProperties[path[1]]
+			}
+
+			path = path[2:]
+		default:
+			return nil, fmt.Errorf("resolving %s: unsupported path part: %s", ref, token)
+		}
+
+		if next == nil {
+			return nil, fmt.Errorf("%s not found", ref)
+		}
+
+		property = next
+	}
+
+	return property, nil
+}
+
+// MarshalJSON is a custom JSON handler for Resource that preserves Extensions.
+func (r Resource) MarshalJSON() ([]byte, error) {
+	type resource Resource
+
+	b, err := json.Marshal(resource(r))
 
 	if err != nil {
 		return nil, err
 	}
 
-	property, ok := properties[field]
-	if !ok || property == nil {
-		return nil, fmt.Errorf("%s/%s not found", typ, field)
+	return marshalExtensions(b, r.Extensions, r.EmptyValues)
+}
+
+// UnmarshalJSON is a custom JSON handler for Resource that captures Extensions
+// and the declared order of Definitions and Properties.
+func (r *Resource) UnmarshalJSON(b []byte) error {
+	type resource Resource
+
+	var v resource
+
+	extensions, emptyValues, err := unmarshalExtensions(b, &v)
+
+	if err != nil {
+		return err
 	}
 
-	return property, nil
+	orders, err := unmarshalKeyOrder(b, "definitions", "properties")
+
+	if err != nil {
+		return err
+	}
+
+	*r = Resource(v)
+	r.EmptyValues = emptyValues
+	r.Extensions = extensions
+	r.DefinitionsOrder = orders[0]
+	r.PropertiesOrder = orders[1]
+
+	return nil
 }
@@ -0,0 +1,510 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	ResourceChangeTypeAdded    = "added"
+	ResourceChangeTypeModified = "modified"
+	ResourceChangeTypeRemoved  = "removed"
+)
+
+// ResourceChange is a single structural difference between two versions of a Resource.
+//
+// Keyword is the schema keyword that changed, e.g. properties for an added or removed property,
+// maxLength or createOnlyProperties. Handler changes are qualified by handler type, e.g. handlers/create/permissions.
+// Pointer is the location of the changed property, if any, with array items as the * wildcard, e.g.
+// /properties/Tags/*/Key. For changes to lists of values, such as required, enum or handler permissions,
+// each added or removed value is a separate change with the value in Before or After; otherwise Before
+// and After hold the whole keyword values.
+type ResourceChange struct {
+	After   interface{}
+	Before  interface{}
+	Keyword string
+	Pointer PropertyJsonPointer
+	Type    string
+}
+
+// String returns a string representation of ResourceChange.
+func (c *ResourceChange) String() string {
+	if c == nil {
+		return ""
+	}
+
+	s := c.Type + " " + c.Keyword
+
+	if c.Pointer != "" {
+		s += " " + c.Pointer.String()
+	}
+
+	switch {
+	case c.Type == ResourceChangeTypeModified:
+		s += fmt.Sprintf(": %s -> %s", diffValueString(c.Before), diffValueString(c.After))
+	case c.After != nil:
+		s += ": " + diffValueString(c.After)
+	case c.Before != nil:
+		s += ": " + diffValueString(c.Before)
+	}
+
+	return s
+}
+
+// ResourceChanges is a list of ResourceChange.
+type ResourceChanges []*ResourceChange
+
+// ByPointer returns the property changes keyed by property pointer.
+func (cs ResourceChanges) ByPointer() map[PropertyJsonPointer]ResourceChanges {
+	m := make(map[PropertyJsonPointer]ResourceChanges)
+
+	for _, c := range cs {
+		if c.Pointer == "" {
+			continue
+		}
+
+		m[c.Pointer] = append(m[c.Pointer], c)
+	}
+
+	return m
+}
+
+// String returns a string representation of ResourceChanges, one per line.
+func (cs ResourceChanges) String() string {
+	var lines []string
+
+	for _, c := range cs {
+		lines = append(lines, c.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Diff returns the structural differences from this Resource to a later version of it.
+//
+// Both Resources are compared in expanded form, using Expanded, so References are followed and
+// either Resource may or may not have been expanded already. Neither Resource is modified.
+func (r *Resource) Diff(after *Resource) (ResourceChanges, error) {
+	if r == nil || after == nil {
+		return nil, fmt.Errorf("diffing Resource: both versions are required")
+	}
+
+	before, err := r.Expanded()
+
+	if err != nil {
+		return nil, fmt.Errorf("diffing Resource: before: %w", err)
+	}
+
+	after, err = after.Expanded()
+
+	if err != nil {
+		return nil, fmt.Errorf("diffing Resource: after: %w", err)
+	}
+
+	d := &resourceDiffer{}
+
+	d.diffFields(reflect.ValueOf(before).Elem(), reflect.ValueOf(after).Elem(), "", "", map[string]bool{
+		"AdditionalIdentifiers":           true,
+		"ConditionalCreateOnlyProperties": true,
+		"CreateOnlyProperties":            true,
+		"Definitions":                     true,
+		"DefinitionsOrder":                true,
+		"DeprecatedProperties":            true,
+		"EmptyValues":                     true,
+		"Handlers":                        true,
+		"NonPublicDefinitions":            true,
+		"NonPublicProperties":             true,
+		"PrimaryIdentifier":               true,
+		"Properties":                      true,
+		"PropertiesOrder":                 true,
+		"ReadOnlyProperties":              true,
+		"Remote":                          true,
+		"Required":                        true,
+		"Tagging":                         true,
+		"WriteOnlyProperties":             true,
+	})
+
+	d.diffProperties(before.Properties, after.Properties, nil, "properties")
+	d.diffRequired(before.Required, after.Required, nil)
+
+	if !reflect.DeepEqual(before.PrimaryIdentifier, after.PrimaryIdentifier) {
+		d.add(ResourceChangeTypeModified, "primaryIdentifier", "", diffPointers(before.PrimaryIdentifier), diffPointers(after.PrimaryIdentifier))
+	}
+
+	if !reflect.DeepEqual(before.AdditionalIdentifiers, after.AdditionalIdentifiers) {
+		d.add(ResourceChangeTypeModified, "additionalIdentifiers", "", before.AdditionalIdentifiers, after.AdditionalIdentifiers)
+	}
+
+	for _, list := range []struct {
+		keyword string
+		before  PropertyJsonPointers
+		after   PropertyJsonPointers
+	}{
+		{"conditionalCreateOnlyProperties", before.ConditionalCreateOnlyProperties, after.ConditionalCreateOnlyProperties},
+		{"createOnlyProperties", before.CreateOnlyProperties, after.CreateOnlyProperties},
+		{"deprecatedProperties", before.DeprecatedProperties, after.DeprecatedProperties},
+		{"nonPublicDefinitions", before.NonPublicDefinitions, after.NonPublicDefinitions},
+		{"nonPublicProperties", before.NonPublicProperties, after.NonPublicProperties},
+		{"readOnlyProperties", before.ReadOnlyProperties, after.ReadOnlyProperties},
+		{"writeOnlyProperties", before.WriteOnlyProperties, after.WriteOnlyProperties},
+	} {
+		d.diffPointerList(list.keyword, list.before, list.after)
+	}
+
+	d.diffHandlers(before.Handlers, after.Handlers)
+
+	var beforeTagging, afterTagging Tagging
+
+	if before.Tagging != nil {
+		beforeTagging = *before.Tagging
+	}
+
+	if after.Tagging != nil {
+		afterTagging = *after.Tagging
+	}
+
+	d.diffFields(reflect.ValueOf(beforeTagging), reflect.ValueOf(afterTagging), "tagging/", "", map[string]bool{
+		"EmptyValues": true,
+	})
+
+	return d.changes, nil
+}
+
+// resourceDiffer accumulates changes while comparing two Resources.
+type resourceDiffer struct {
+	changes ResourceChanges
+}
+
+// add records a change.
+func (d *resourceDiffer) add(typ, keyword string, pointer PropertyJsonPointer, before, after interface{}) {
+	d.changes = append(d.changes, &ResourceChange{
+		After:   after,
+		Before:  before,
+		Keyword: keyword,
+		Pointer: pointer,
+		Type:    typ,
+	})
+}
+
+// diffFields compares the exported fields of two struct values, other than those skipped,
+// recording a change for each keyword whose value differs.
+func (d *resourceDiffer) diffFields(before, after reflect.Value, prefix string, pointer PropertyJsonPointer, skip map[string]bool) {
+	for i := 0; i < before.NumField(); i++ {
+		field := before.Type().Field(i)
+
+		if !field.IsExported() || skip[field.Name] {
+			continue
+		}
+
+		if field.Name == "Extensions" {
+			d.diffExtensions(before.Field(i).Interface().(Extensions), after.Field(i).Interface().(Extensions), prefix, pointer)
+			continue
+		}
+
+		beforeValue := diffValue(before.Field(i))
+		afterValue := diffValue(after.Field(i))
+
+		if diffEqual(beforeValue, afterValue) {
+			continue
+		}
+
+		keyword := prefix + strings.Split(field.Tag.Get("json"), ",")[0]
+
+		switch {
+		case beforeValue == nil:
+			d.add(ResourceChangeTypeAdded, keyword, pointer, nil, afterValue)
+		case afterValue == nil:
+			d.add(ResourceChangeTypeRemoved, keyword, pointer, beforeValue, nil)
+		default:
+			d.add(ResourceChangeTypeModified, keyword, pointer, beforeValue, afterValue)
+		}
+	}
+}
+
+// diffExtensions compares extension keywords.
+func (d *resourceDiffer) diffExtensions(before, after Extensions, prefix string, pointer PropertyJsonPointer) {
+	keys := unionStrings(before.Keys(), after.Keys())
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		beforeValue, beforeOk := before[key]
+		afterValue, afterOk := after[key]
+
+		switch {
+		case !beforeOk:
+			d.add(ResourceChangeTypeAdded, prefix+key, pointer, nil, afterValue)
+		case !afterOk:
+			d.add(ResourceChangeTypeRemoved, prefix+key, pointer, beforeValue, nil)
+		case !diffJSONEqual(beforeValue, afterValue):
+			d.add(ResourceChangeTypeModified, prefix+key, pointer, beforeValue, afterValue)
+		}
+	}
+}
+
+// diffHandlers compares handlers and their permissions.
+func (d *resourceDiffer) diffHandlers(before, after map[string]*Handler) {
+	handlerTypes := unionStrings(sortedHandlerTypes(before), sortedHandlerTypes(after))
+
+	sort.Strings(handlerTypes)
+
+	for _, handlerType := range handlerTypes {
+		keyword := "handlers/" + handlerType
+		beforeHandler, afterHandler := before[handlerType], after[handlerType]
+
+		switch {
+		case beforeHandler == nil && afterHandler == nil:
+			continue
+		case beforeHandler == nil:
+			d.add(ResourceChangeTypeAdded, keyword, "", nil, nil)
+			beforeHandler = &Handler{}
+		case afterHandler == nil:
+			d.add(ResourceChangeTypeRemoved, keyword, "", nil, nil)
+			afterHandler = &Handler{}
+		}
+
+		d.diffValues(keyword+"/permissions", "", stringValues(beforeHandler.Permissions), stringValues(afterHandler.Permissions))
+		d.diffFields(reflect.ValueOf(*beforeHandler), reflect.ValueOf(*afterHandler), keyword+"/", "", map[string]bool{
+			"EmptyValues":    true,
+			"Permissions":    true,
+			"SourcePosition": true,
+		})
+	}
+}
+
+// diffPointerList compares a list of property pointers, such as createOnlyProperties.
+func (d *resourceDiffer) diffPointerList(keyword string, before, after PropertyJsonPointers) {
+	for _, pointer := range before {
+		if !containsPointer(after, pointer) {
+			d.add(ResourceChangeTypeRemoved, keyword, pointer, nil, nil)
+		}
+	}
+
+	for _, pointer := range after {
+		if !containsPointer(before, pointer) {
+			d.add(ResourceChangeTypeAdded, keyword, pointer, nil, nil)
+		}
+	}
+}
+
+// diffProperties compares two name-to-property maps of the object at path.
+func (d *resourceDiffer) diffProperties(before, after map[string]*Property, path []string, keyword string) {
+	names := unionStrings(sortedPropertyNames(before), sortedPropertyNames(after))
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		propertyPath := appendPath(path, name)
+		beforeProperty, beforeOk := before[name]
+		afterProperty, afterOk := after[name]
+
+		switch {
+		case !beforeOk:
+			d.add(ResourceChangeTypeAdded, keyword, NewPropertyJsonPointer(propertyPath...), nil, nil)
+		case !afterOk:
+			d.add(ResourceChangeTypeRemoved, keyword, NewPropertyJsonPointer(propertyPath...), nil, nil)
+		default:
+			d.diffProperty(beforeProperty, afterProperty, propertyPath)
+		}
+	}
+}
+
+// diffProperty compares two versions of the property at path, including all nested properties.
+func (d *resourceDiffer) diffProperty(before, after *Property, path []string) {
+	if before == nil {
+		before = &Property{}
+	}
+
+	if after == nil {
+		after = &Property{}
+	}
+
+	pointer := NewPropertyJsonPointer(path...)
+
+	d.diffFields(reflect.ValueOf(*before), reflect.ValueOf(*after), "", pointer, map[string]bool{
+		"EmptyValues":            true,
+		"Enum":                   true,
+		"Items":                  true,
+		"PatternProperties":      true,
+		"PatternPropertiesOrder": true,
+		"Properties":             true,
+		"PropertiesOrder":        true,
+		"RecursiveRef":           true,
+		"Required":               true,
+		"ResolvedRefs":           true,
+		"SourcePosition":         true,
+		"UnwrappedOneOf":         true,
+	})
+
+	if !reflect.DeepEqual(before.RecursiveRef, after.RecursiveRef) {
+		d.add(ResourceChangeTypeModified, "$ref", pointer, diffValue(reflect.ValueOf(before.RecursiveRef)), diffValue(reflect.ValueOf(after.RecursiveRef)))
+	}
+
+	// A new or removed enum restriction is a single change of the whole list.
+	switch {
+	case len(before.Enum) == 0 && len(after.Enum) > 0:
+		d.add(ResourceChangeTypeAdded, "enum", pointer, nil, after.Enum)
+	case len(before.Enum) > 0 && len(after.Enum) == 0:
+		d.add(ResourceChangeTypeRemoved, "enum", pointer, before.Enum, nil)
+	default:
+		d.diffValues("enum", pointer, before.Enum, after.Enum)
+	}
+
+	d.diffRequired(before.Required, after.Required, path)
+
+	switch {
+	case before.Items == nil && after.Items != nil:
+		d.add(ResourceChangeTypeAdded, "items", pointer, nil, nil)
+	case before.Items != nil && after.Items == nil:
+		d.add(ResourceChangeTypeRemoved, "items", pointer, nil, nil)
+	case before.Items != nil:
+		d.diffProperty(before.Items, after.Items, appendPath(path, PropertyJsonPointerWildcard))
+	}
+
+	d.diffProperties(before.PatternProperties, after.PatternProperties, path, "patternProperties")
+	d.diffProperties(before.Properties, after.Properties, path, "properties")
+}
+
+// diffRequired compares the required property names of the object at path.
+func (d *resourceDiffer) diffRequired(before, after []string, path []string) {
+	for _, name := range subtractStrings(before, after) {
+		d.add(ResourceChangeTypeRemoved, "required", NewPropertyJsonPointer(appendPath(path, name)...), nil, nil)
+	}
+
+	for _, name := range subtractStrings(after, before) {
+		d.add(ResourceChangeTypeAdded, "required", NewPropertyJsonPointer(appendPath(path, name)...), nil, nil)
+	}
+}
+
+// diffValues compares lists of values, such as enum values, recording each added or removed value.
+func (d *resourceDiffer) diffValues(keyword string, pointer PropertyJsonPointer, before, after []interface{}) {
+	for _, value := range before {
+		if !containsValue(after, value) {
+			d.add(ResourceChangeTypeRemoved, keyword, pointer, value, nil)
+		}
+	}
+
+	for _, value := range after {
+		if !containsValue(before, value) {
+			d.add(ResourceChangeTypeAdded, keyword, pointer, nil, value)
+		}
+	}
+}
+
+// containsPointer returns true if the list contains the pointer.
+func containsPointer(pointers PropertyJsonPointers, pointer PropertyJsonPointer) bool {
+	for _, p := range pointers {
+		if p == pointer {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsValue returns true if the list contains a value equal to the value.
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// diffEqual returns true if two field values are equal. Values such as subschemas, handler schemas or
+// the type configuration are compared in their JSON form, so that nested properties at different
+// SourcePositions are equal.
+func diffEqual(before, after interface{}) bool {
+	if reflect.DeepEqual(before, after) {
+		return true
+	}
+
+	if before == nil || after == nil {
+		return false
+	}
+
+	a, err := json.Marshal(before)
+
+	if err != nil {
+		return false
+	}
+
+	b, err := json.Marshal(after)
+
+	if err != nil {
+		return false
+	}
+
+	return diffJSONEqual(a, b)
+}
+
+// diffJSONEqual returns true if two JSON documents are semantically equal.
+func diffJSONEqual(a, b json.RawMessage) bool {
+	var x, y interface{}
+
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return string(a) == string(b)
+	}
+
+	return reflect.DeepEqual(x, y)
+}
+
+// diffPointers returns the string values of a list of property pointers.
+func diffPointers(pointers PropertyJsonPointers) []string {
+	var values []string
+
+	for _, pointer := range pointers {
+		values = append(values, pointer.String())
+	}
+
+	return values
+}
+
+// diffValue returns the value of a field for comparison, dereferencing pointers and
+// returning nil for zero or empty values.
+func diffValue(v reflect.Value) interface{} {
+	if v.IsZero() {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		return v.Elem().Interface()
+	case reflect.Map, reflect.Slice:
+		if v.Len() == 0 {
+			return nil
+		}
+	}
+
+	return v.Interface()
+}
+
+// diffValueString returns the JSON representation of a changed value.
+func diffValueString(v interface{}) string {
+	b, err := json.Marshal(v)
+
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	return string(b)
+}
+
+// stringValues returns a list of strings as a list of values.
+func stringValues(values []string) []interface{} {
+	var s []interface{}
+
+	for _, value := range values {
+		s = append(s, value)
+	}
+
+	return s
+}
@@ -0,0 +1,355 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	ChangeRuleAdditionalPropertiesRestricted = "additional-properties-restricted"
+	ChangeRuleConstraintAdded                = "constraint-added"
+	ChangeRuleCreateOnlyAdded                = "create-only-added"
+	ChangeRuleEnumAdded                      = "enum-added"
+	ChangeRuleEnumValueRemoved               = "enum-value-removed"
+	ChangeRuleHandlerPermissionAdded         = "handler-permission-added"
+	ChangeRuleHandlerRemoved                 = "handler-removed"
+	ChangeRuleMaximumDecreased               = "maximum-decreased"
+	ChangeRuleMinimumIncreased               = "minimum-increased"
+	ChangeRulePatternChanged                 = "pattern-changed"
+	ChangeRulePrimaryIdentifierChanged       = "primary-identifier-changed"
+	ChangeRulePropertyRemoved                = "property-removed"
+	ChangeRuleReadOnlyAdded                  = "read-only-added"
+	ChangeRuleRequiredAdded                  = "required-added"
+	ChangeRuleTaggingRemoved                 = "tagging-removed"
+	ChangeRuleTypeChanged                    = "type-changed"
+	ChangeRuleTypeNameChanged                = "type-name-changed"
+	ChangeRuleWriteOnlyAdded                 = "write-only-added"
+)
+
+// ChangeRule classifies the ResourceChanges it matches as breaking or non-breaking.
+type ChangeRule struct {
+	Breaking    bool
+	Description string
+	ID          string
+	Match       func(*ResourceChange) bool
+}
+
+// ClassifiedChange is a ResourceChange with the ChangeRule that classified it.
+// Rule is empty for changes matched by no rule, which are non-breaking.
+type ClassifiedChange struct {
+	Breaking bool
+	Change   *ResourceChange
+	Rule     string
+}
+
+// String returns a string representation of ClassifiedChange.
+func (c *ClassifiedChange) String() string {
+	if c == nil {
+		return ""
+	}
+
+	if !c.Breaking {
+		return c.Change.String()
+	}
+
+	return fmt.Sprintf("BREAKING [%s] %s", c.Rule, c.Change)
+}
+
+// ClassifiedChanges is a list of ClassifiedChange.
+type ClassifiedChanges []*ClassifiedChange
+
+// Breaking returns only the breaking changes.
+func (cs ClassifiedChanges) Breaking() ClassifiedChanges {
+	var breaking ClassifiedChanges
+
+	for _, c := range cs {
+		if c.Breaking {
+			breaking = append(breaking, c)
+		}
+	}
+
+	return breaking
+}
+
+// HasBreaking returns true if any change is breaking.
+func (cs ClassifiedChanges) HasBreaking() bool {
+	return len(cs.Breaking()) > 0
+}
+
+// String returns a string representation of ClassifiedChanges, one per line.
+func (cs ClassifiedChanges) String() string {
+	var lines []string
+
+	for _, c := range cs {
+		lines = append(lines, c.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Classify classifies each change using the first matching rule.
+// If no rules are provided, DefaultChangeRules are used.
+func (cs ResourceChanges) Classify(rules ...ChangeRule) ClassifiedChanges {
+	if len(rules) == 0 {
+		rules = DefaultChangeRules()
+	}
+
+	var classified ClassifiedChanges
+
+	for _, change := range cs {
+		c := &ClassifiedChange{
+			Change: change,
+		}
+
+		for _, rule := range rules {
+			if rule.Match != nil && rule.Match(change) {
+				c.Breaking = rule.Breaking
+				c.Rule = rule.ID
+				break
+			}
+		}
+
+		classified = append(classified, c)
+	}
+
+	return classified
+}
+
+// ClassifyChanges returns the classified differences from this Resource to a later version of it.
+// See Diff and ResourceChanges.Classify.
+func (r *Resource) ClassifyChanges(after *Resource, rules ...ChangeRule) (ClassifiedChanges, error) {
+	changes, err := r.Diff(after)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return changes.Classify(rules...), nil
+}
+
+// DefaultChangeRules returns the built-in rules, all classifying changes as breaking for consumers
+// of the resource type. Any other change is non-breaking.
+//
+//	Rule                               Breaking change
+//	additional-properties-restricted   additionalProperties added or changed to false
+//	constraint-added                   const, format, multipleOf, uniqueItems or items added or changed
+//	create-only-added                  property added to createOnlyProperties, so updates now replace the resource
+//	enum-added                         enum added to a property that had none
+//	enum-value-removed                 value removed from an enum
+//	handler-permission-added           permission added to a handler, so callers need more permissions
+//	handler-removed                    handler removed
+//	maximum-decreased                  maximum, exclusiveMaximum, maxLength, maxItems or maxProperties added or decreased
+//	minimum-increased                  minimum, exclusiveMinimum, minLength, minItems or minProperties added or increased
+//	pattern-changed                    pattern added or changed
+//	primary-identifier-changed         primaryIdentifier changed
+//	property-removed                   property removed
+//	read-only-added                    property added to readOnlyProperties, so it can no longer be configured
+//	required-added                     property became required
+//	tagging-removed                    resource or tagging taggable changed to false
+//	type-changed                       type added or changed
+//	type-name-changed                  typeName changed
+//	write-only-added                   property added to writeOnlyProperties, so it can no longer be read
+func DefaultChangeRules() []ChangeRule {
+	return []ChangeRule{
+		{
+			Breaking:    true,
+			Description: "additionalProperties added or changed to false",
+			ID:          ChangeRuleAdditionalPropertiesRestricted,
+			Match: func(c *ResourceChange) bool {
+				return c.Keyword == "additionalProperties" && c.After == false
+			},
+		},
+		{
+			Breaking:    true,
+			Description: "const, format, multipleOf, uniqueItems or items added or changed",
+			ID:          ChangeRuleConstraintAdded,
+			Match: func(c *ResourceChange) bool {
+				switch c.Keyword {
+				case "const", "format", "multipleOf", "items":
+					return c.Type != ResourceChangeTypeRemoved
+				case "uniqueItems":
+					return c.After == true
+				}
+
+				return false
+			},
+		},
+		{
+			Breaking:    true,
+			Description: "property added to createOnlyProperties",
+			ID:          ChangeRuleCreateOnlyAdded,
+			Match:       matchChange(ResourceChangeTypeAdded, "createOnlyProperties"),
+		},
+		{
+			Breaking:    true,
+			Description: "enum added to a property that had none",
+			ID:          ChangeRuleEnumAdded,
+			Match: func(c *ResourceChange) bool {
+				_, ok := c.After.([]interface{})
+
+				return c.Keyword == "enum" && c.Type == ResourceChangeTypeAdded && ok
+			},
+		},
+		{
+			Breaking:    true,
+			Description: "value removed from an enum",
+			ID:          ChangeRuleEnumValueRemoved,
+			Match: func(c *ResourceChange) bool {
+				_, ok := c.Before.([]interface{})
+
+				return c.Keyword == "enum" && c.Type == ResourceChangeTypeRemoved && !ok
+			},
+		},
+		{
+			Breaking:    true,
+			Description: "permission added to a handler",
+			ID:          ChangeRuleHandlerPermissionAdded,
+			Match: func(c *ResourceChange) bool {
+				return c.Type == ResourceChangeTypeAdded && strings.HasPrefix(c.Keyword, "handlers/") && strings.HasSuffix(c.Keyword, "/permissions")
+			},
+		},
+		{
+			Breaking:    true,
+			Description: "handler removed",
+			ID:          ChangeRuleHandlerRemoved,
+			Match: func(c *ResourceChange) bool {
+				return c.Type == ResourceChangeTypeRemoved && strings.HasPrefix(c.Keyword, "handlers/") && strings.Count(c.Keyword, "/") == 1
+			},
+		},
+		{
+			Breaking:    true,
+			Description: "maximum, exclusiveMaximum, maxLength, maxItems or maxProperties added or decreased",
+			ID:          ChangeRuleMaximumDecreased,
+			Match: func(c *ResourceChange) bool {
+				switch c.Keyword {
+				case "maximum", "exclusiveMaximum", "maxLength", "maxItems", "maxProperties":
+					return c.Type == ResourceChangeTypeAdded || (c.Type == ResourceChangeTypeModified && compareChangeValues(c) < 0)
+				}
+
+				return false
+			},
+		},
+		{
+			Breaking:    true,
+			Description: "minimum, exclusiveMinimum, minLength, minItems or minProperties added or increased",
+			ID:          ChangeRuleMinimumIncreased,
+			Match: func(c *ResourceChange) bool {
+				switch c.Keyword {
+				case "minimum", "exclusiveMinimum", "minLength", "minItems", "minProperties":
+					return c.Type == ResourceChangeTypeAdded || (c.Type == ResourceChangeTypeModified && compareChangeValues(c) > 0)
+				}
+
+				return false
+			},
+		},
+		{
+			Breaking:    true,
+			Description: "pattern added or changed",
+			ID:          ChangeRulePatternChanged,
+			Match: func(c *ResourceChange) bool {
+				return c.Keyword == "pattern" && c.Type != ResourceChangeTypeRemoved
+			},
+		},
+		{
+			Breaking:    true,
+			Description: "primaryIdentifier changed",
+			ID:          ChangeRulePrimaryIdentifierChanged,
+			Match: func(c *ResourceChange) bool {
+				return c.Keyword == "primaryIdentifier"
+			},
+		},
+		{
+			Breaking:    true,
+			Description: "property removed",
+			ID:          ChangeRulePropertyRemoved,
+			Match: func(c *ResourceChange) bool {
+				return c.Type == ResourceChangeTypeRemoved && (c.Keyword == "properties" || c.Keyword == "patternProperties")
+			},
+		},
+		{
+			Breaking:    true,
+			Description: "property added to readOnlyProperties",
+			ID:          ChangeRuleReadOnlyAdded,
+			Match:       matchChange(ResourceChangeTypeAdded, "readOnlyProperties"),
+		},
+		{
+			Breaking:    true,
+			Description: "property became required",
+			ID:          ChangeRuleRequiredAdded,
+			Match:       matchChange(ResourceChangeTypeAdded, "required"),
+		},
+		{
+			Breaking:    true,
+			Description: "resource or tagging taggable changed to false",
+			ID:          ChangeRuleTaggingRemoved,
+			Match: func(c *ResourceChange) bool {
+				return (c.Keyword == "taggable" || c.Keyword == "tagging/taggable") && c.After == false
+			},
+		},
+		{
+			Breaking:    true,
+			Description: "type added or changed",
+			ID:          ChangeRuleTypeChanged,
+			Match: func(c *ResourceChange) bool {
+				return c.Keyword == "type" && c.Type != ResourceChangeTypeRemoved
+			},
+		},
+		{
+			Breaking:    true,
+			Description: "typeName changed",
+			ID:          ChangeRuleTypeNameChanged,
+			Match: func(c *ResourceChange) bool {
+				return c.Keyword == "typeName" && c.Pointer == ""
+			},
+		},
+		{
+			Breaking:    true,
+			Description: "property added to writeOnlyProperties",
+			ID:          ChangeRuleWriteOnlyAdded,
+			Match:       matchChange(ResourceChangeTypeAdded, "writeOnlyProperties"),
+		},
+	}
+}
+
+// matchChange returns a ChangeRule Match function for a change type and keyword.
+func matchChange(typ, keyword string) func(*ResourceChange) bool {
+	return func(c *ResourceChange) bool {
+		return c.Type == typ && c.Keyword == keyword
+	}
+}
+
+// compareChangeValues compares the numeric After value of a change with its Before value,
+// returning -1, 0 or 1. Non-numeric values compare as equal.
+func compareChangeValues(c *ResourceChange) int {
+	before, beforeOk := changeNumber(c.Before)
+	after, afterOk := changeNumber(c.After)
+
+	switch {
+	case !beforeOk || !afterOk:
+		return 0
+	case after < before:
+		return -1
+	case after > before:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// changeNumber returns a numeric change value as a float64.
+func changeNumber(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+
+		return f, err == nil
+	}
+
+	return 0, false
+}
@@ -0,0 +1,124 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestResourceClassifyChanges(t *testing.T) {
+	testCases := []struct {
+		TestDescription string
+		Before          string
+		After           string
+		Rules           []cfschema.ChangeRule
+		ExpectBreaking  bool
+		Expected        []string
+	}{
+		{
+			TestDescription: "non-breaking",
+			Before:          `{"typeName": "Initech::TPS::Report", "description": "TPS", "properties": {"Title": {"type": "string", "maxLength": 100, "enum": ["A"]}}, "required": ["Title"], "handlers": {"create": {"permissions": ["initech:CreateReport", "initech:TagReport"]}}}`,
+			After:           `{"typeName": "Initech::TPS::Report", "description": "TPS report", "properties": {"Title": {"type": "string", "maxLength": 250, "enum": ["A", "B"]}, "Pages": {"type": "integer"}}, "handlers": {"create": {"permissions": ["initech:CreateReport"]}, "read": {"permissions": ["initech:DescribeReport"]}}}`,
+			Expected: []string{
+				`modified description: "TPS" -> "TPS report"`,
+				`added properties /properties/Pages`,
+				`modified maxLength /properties/Title: 100 -> 250`,
+				`added enum /properties/Title: "B"`,
+				`removed required /properties/Title`,
+				`removed handlers/create/permissions: "initech:TagReport"`,
+				`added handlers/read`,
+				`BREAKING [handler-permission-added] added handlers/read/permissions: "initech:DescribeReport"`,
+			},
+			ExpectBreaking: true,
+		},
+		{
+			TestDescription: "property constraints",
+			Before:          `{"typeName": "Initech::TPS::Report", "properties": {"Title": {"type": "string", "maxLength": 250, "enum": ["A", "B"]}, "Pages": {"type": "integer", "minimum": 1}, "Code": {"type": "string"}, "Removed": {"type": "string"}}}`,
+			After:           `{"typeName": "Initech::TPS::Report", "properties": {"Title": {"type": "string", "maxLength": 100, "enum": ["A"]}, "Pages": {"type": "number", "minimum": 2}, "Code": {"type": "string", "pattern": "^[A-Z]+$", "enum": ["X"]}}, "required": ["Code"]}`,
+			Expected: []string{
+				`BREAKING [pattern-changed] added pattern /properties/Code: "^[A-Z]+$"`,
+				`BREAKING [enum-added] added enum /properties/Code: ["X"]`,
+				`BREAKING [minimum-increased] modified minimum /properties/Pages: 1 -> 2`,
+				`BREAKING [type-changed] modified type /properties/Pages: "integer" -> "number"`,
+				`BREAKING [property-removed] removed properties /properties/Removed`,
+				`BREAKING [maximum-decreased] modified maxLength /properties/Title: 250 -> 100`,
+				`BREAKING [enum-value-removed] removed enum /properties/Title: "B"`,
+				`BREAKING [required-added] added required /properties/Code`,
+			},
+			ExpectBreaking: true,
+		},
+		{
+			TestDescription: "resource metadata",
+			Before:          `{"typeName": "Initech::TPS::Report", "properties": {"Id": {"type": "string"}, "Title": {"type": "string"}}, "primaryIdentifier": ["/properties/Id"], "handlers": {"delete": {"permissions": ["initech:DeleteReport"]}}, "tagging": {"taggable": true}}`,
+			After:           `{"typeName": "Initech::TPS::Report", "properties": {"Id": {"type": "string"}, "Title": {"type": "string"}}, "primaryIdentifier": ["/properties/Title"], "createOnlyProperties": ["/properties/Title"], "readOnlyProperties": ["/properties/Id"], "tagging": {"taggable": false}}`,
+			Expected: []string{
+				`BREAKING [primary-identifier-changed] modified primaryIdentifier: ["/properties/Id"] -> ["/properties/Title"]`,
+				`BREAKING [create-only-added] added createOnlyProperties /properties/Title`,
+				`BREAKING [read-only-added] added readOnlyProperties /properties/Id`,
+				`BREAKING [handler-removed] removed handlers/delete`,
+				`removed handlers/delete/permissions: "initech:DeleteReport"`,
+				`BREAKING [tagging-removed] modified tagging/taggable: true -> false`,
+			},
+			ExpectBreaking: true,
+		},
+		{
+			TestDescription: "custom rules",
+			Before:          `{"typeName": "Initech::TPS::Report", "description": "TPS", "properties": {"Title": {"type": "string"}}, "required": ["Title"]}`,
+			After:           `{"typeName": "Initech::TPS::Report", "description": "TPS report", "properties": {"Title": {"type": "string"}}}`,
+			Rules: []cfschema.ChangeRule{
+				{
+					Breaking: true,
+					ID:       "description-changed",
+					Match: func(c *cfschema.ResourceChange) bool {
+						return c.Keyword == "description"
+					},
+				},
+			},
+			Expected: []string{
+				`BREAKING [description-changed] modified description: "TPS" -> "TPS report"`,
+				`removed required /properties/Title`,
+			},
+			ExpectBreaking: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			var before, after cfschema.Resource
+
+			if err := json.Unmarshal([]byte(testCase.Before), &before); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err := json.Unmarshal([]byte(testCase.After), &after); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			changes, err := before.ClassifyChanges(&after, testCase.Rules...)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if actual, expected := changes.HasBreaking(), testCase.ExpectBreaking; actual != expected {
+				t.Errorf("expected breaking (%t), got: %t", expected, actual)
+			}
+
+			if actual, expected := len(changes), len(testCase.Expected); actual != expected {
+				t.Fatalf("expected %d changes, got %d:\n%s", expected, actual, changes)
+			}
+
+			for i, change := range changes {
+				if actual, expected := change.String(), testCase.Expected[i]; actual != expected {
+					t.Errorf("expected change (%s), got: %s", expected, actual)
+				}
+			}
+		})
+	}
+}
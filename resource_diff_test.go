@@ -0,0 +1,166 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestResourceDiff(t *testing.T) {
+	testCases := []struct {
+		TestDescription string
+		Before          string
+		After           string
+		Expected        []string
+	}{
+		{
+			TestDescription: "no changes",
+			Before:          `{"typeName": "Initech::TPS::Report", "properties": {"Title": {"type": "string"}}}`,
+			After:           `{"typeName": "Initech::TPS::Report", "properties": {"Title": {"type": "string"}}}`,
+		},
+		{
+			TestDescription: "properties",
+			Before:          `{"typeName": "Initech::TPS::Report", "properties": {"Title": {"type": "string", "maxLength": 250}, "Pages": {"type": "integer"}, "Status": {"type": "string", "enum": ["DRAFT", "FINAL"]}}}`,
+			After:           `{"typeName": "Initech::TPS::Report", "properties": {"Title": {"type": "string", "maxLength": 100, "pattern": "^[A-Z]"}, "Pages": {"type": "number"}, "Status": {"type": "string", "enum": ["FINAL", "ARCHIVED"]}, "Cover": {"type": "boolean"}}, "required": ["Title"]}`,
+			Expected: []string{
+				`added properties /properties/Cover`,
+				`modified type /properties/Pages: "integer" -> "number"`,
+				`removed enum /properties/Status: "DRAFT"`,
+				`added enum /properties/Status: "ARCHIVED"`,
+				`modified maxLength /properties/Title: 250 -> 100`,
+				`added pattern /properties/Title: "^[A-Z]"`,
+				`added required /properties/Title`,
+			},
+		},
+		{
+			TestDescription: "nested properties",
+			Before:          `{"typeName": "Initech::TPS::Report", "definitions": {"Memo": {"type": "object", "properties": {"Heading": {"type": "string"}}}}, "properties": {"Memos": {"type": "array", "items": {"$ref": "#/definitions/Memo"}}}}`,
+			After:           `{"typeName": "Initech::TPS::Report", "definitions": {"Memo": {"type": "object", "properties": {"Body": {"type": "string"}}, "required": ["Body"]}}, "properties": {"Memos": {"type": "array", "items": {"$ref": "#/definitions/Memo"}}}}`,
+			Expected: []string{
+				`added required /properties/Memos/*/Body`,
+				`added properties /properties/Memos/*/Body`,
+				`removed properties /properties/Memos/*/Heading`,
+			},
+		},
+		{
+			TestDescription: "resource metadata",
+			Before:          `{"typeName": "Initech::TPS::Report", "description": "TPS", "properties": {"Id": {"type": "string"}, "Title": {"type": "string"}}, "primaryIdentifier": ["/properties/Id"], "createOnlyProperties": ["/properties/Id"], "readOnlyProperties": ["/properties/Id"], "handlers": {"create": {"permissions": ["initech:CreateReport"]}, "delete": {"permissions": ["initech:DeleteReport"]}}, "tagging": {"taggable": false}}`,
+			After:           `{"typeName": "Initech::TPS::Report", "description": "TPS report", "properties": {"Id": {"type": "string"}, "Title": {"type": "string"}}, "primaryIdentifier": ["/properties/Id", "/properties/Title"], "createOnlyProperties": ["/properties/Id", "/properties/Title"], "handlers": {"create": {"permissions": ["initech:CreateReport", "initech:TagReport"], "timeoutInMinutes": 60}, "read": {"permissions": ["initech:DescribeReport"]}}, "tagging": {"taggable": true}}`,
+			Expected: []string{
+				`modified description: "TPS" -> "TPS report"`,
+				`modified primaryIdentifier: ["/properties/Id"] -> ["/properties/Id","/properties/Title"]`,
+				`added createOnlyProperties /properties/Title`,
+				`removed readOnlyProperties /properties/Id`,
+				`added handlers/create/permissions: "initech:TagReport"`,
+				`added handlers/create/timeoutInMinutes: 60`,
+				`removed handlers/delete`,
+				`removed handlers/delete/permissions: "initech:DeleteReport"`,
+				`added handlers/read`,
+				`added handlers/read/permissions: "initech:DescribeReport"`,
+				`modified tagging/taggable: false -> true`,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			var before, after cfschema.Resource
+
+			if err := json.Unmarshal([]byte(testCase.Before), &before); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err := json.Unmarshal([]byte(testCase.After), &after); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			changes, err := before.Diff(&after)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if actual, expected := len(changes), len(testCase.Expected); actual != expected {
+				t.Fatalf("expected %d changes, got %d:\n%s", expected, actual, changes)
+			}
+
+			for i, change := range changes {
+				if actual, expected := change.String(), testCase.Expected[i]; actual != expected {
+					t.Errorf("expected change (%s), got: %s", expected, actual)
+				}
+			}
+
+			if before.Definitions != nil && before.Properties["Memos"].Items.Ref == nil {
+				t.Error("expected before Resource to be unmodified")
+			}
+		})
+	}
+}
+
+func TestResourceDiff_SourcePositions(t *testing.T) {
+	schema := `{
+		"typeName": "Initech::TPS::Report",
+		"properties": {
+			"Cfg": {"type": "object", "allOf": [{"properties": {"Name": {"type": "string"}}}]},
+			"Title": {"type": "string", "oneOf": [{"properties": {"Heading": {"type": "string"}}}]}
+		},
+		"handlers": {
+			"create": {"permissions": ["initech:CreateReport"], "handlerSchema": {"properties": {"Filter": {"type": "string"}}}}
+		},
+		"typeConfiguration": {"properties": {"Token": {"type": "string"}}, "additionalProperties": false}
+	}`
+
+	var resources []*cfschema.Resource
+
+	// The same schema, with its properties and handlers at different SourcePositions.
+	for _, document := range []string{schema, "\n\n" + schema} {
+		js, err := cfschema.NewResourceJsonSchemaDocument(document)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		resource, err := js.Resource()
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		resources = append(resources, resource)
+	}
+
+	changes, err := resources[0].Diff(resources[1])
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(changes) > 0 {
+		t.Errorf("expected no changes, got:\n%s", changes)
+	}
+}
+
+func TestResourceDiff_Identical(t *testing.T) {
+	resource := loadAndValidateResourceSchema(t, "provider.definition.schema.v1.json", "AWS_S3Outposts_Bucket.json")
+	expanded, err := resource.Expanded()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	changes, err := resource.Diff(expanded)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(changes) > 0 {
+		t.Errorf("expected no changes, got:\n%s", changes)
+	}
+}
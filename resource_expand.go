@@ -5,29 +5,159 @@ package cfschema
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 )
 
+// ExpandOption configures Expand.
+type ExpandOption func(*expandState)
+
+// WithExpandMaxDepth limits how many References may be nested while expanding, returning an error when exceeded.
+// The default of zero is unlimited; recursive definitions are always detected regardless of this limit.
+func WithExpandMaxDepth(depth int) ExpandOption {
+	return func(s *expandState) {
+		s.maxDepth = depth
+	}
+}
+
 // Expand replaces all Definition and Property JSON Pointer references with their content.
 // This functionality removes the need for recursive logic when accessing Definitions and Properties.
 // In unresolved form nested properties are not allowed, instead nested properties use a '$ref' JSON Pointer to reference a definition.
 // See https://docs.aws.amazon.com/cloudformation-cli/latest/userguide/resource-type-schema.html#schema-properties-properties.
-func (r *Resource) Expand() error {
+//
+// Each expanded property records the References it was resolved from in ResolvedRefs, starting with
+// the Reference it contained, e.g. #/definitions/ClusterConfiguration.
+//
+// Recursive definitions are not inlined infinitely. A reference to a definition which is already being
+// expanded is instead replaced by a Property with RecursiveRef set to that reference.
+func (r *Resource) Expand(opts ...ExpandOption) error {
 	if r == nil {
 		return nil
 	}
 
-	err := r.ResolveProperties(r.Definitions)
+	state := newExpandState()
+
+	for _, opt := range opts {
+		opt(state)
+	}
+
+	r.expandState = state
+	defer func() { r.expandState = nil }()
+
+	err := r.expandProperties(r.Definitions, ReferenceTypeDefinitions)
 
 	if err != nil {
 		return fmt.Errorf("expanding Resource (%s) Definitions: %w", *r.TypeName, err)
 	}
 
-	err = r.ResolveProperties(r.Properties)
+	err = r.expandProperties(r.Properties, ReferenceTypeProperties)
 
 	if err != nil {
 		return fmt.Errorf("expanding Resource (%s) Properties: %w", *r.TypeName, err)
 	}
 
+	if r.TypeConfiguration != nil {
+		err = r.ResolveProperties(r.TypeConfiguration.Properties)
+
+		if err != nil {
+			return fmt.Errorf("expanding Resource (%s) TypeConfiguration Properties: %w", *r.TypeName, err)
+		}
+	}
+
+	remoteNames := make([]string, 0, len(r.Remote))
+
+	for remoteName := range r.Remote {
+		remoteNames = append(remoteNames, remoteName)
+	}
+
+	sort.Strings(remoteNames)
+
+	for _, remoteName := range remoteNames {
+		remote := r.Remote[remoteName]
+
+		if remote == nil {
+			continue
+		}
+
+		err = r.expandProperties(remote.Definitions, ReferenceTypeRemote, remoteName, ReferenceTypeDefinitions)
+
+		if err != nil {
+			return fmt.Errorf("expanding Resource (%s) Remote (%s) Definitions: %w", *r.TypeName, remoteName, err)
+		}
+
+		err = r.expandProperties(remote.Properties, ReferenceTypeRemote, remoteName, ReferenceTypeProperties)
+
+		if err != nil {
+			return fmt.Errorf("expanding Resource (%s) Remote (%s) Properties: %w", *r.TypeName, remoteName, err)
+		}
+	}
+
+	return nil
+}
+
+// Expanded returns an expanded deep copy of the Resource, leaving the Resource itself unmodified.
+// Unlike Expand, where properties resolved from the same Reference share nested Properties and Items,
+// every property of the returned Resource is independent of all others.
+func (r *Resource) Expanded(opts ...ExpandOption) (*Resource, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	c := r.Clone()
+
+	if err := c.Expand(append(opts[:len(opts):len(opts)], withExpandIndependent())...); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// withExpandIndependent inlines a copy of each Reference target rather than sharing its content.
+func withExpandIndependent() ExpandOption {
+	return func(s *expandState) {
+		s.independent = true
+	}
+}
+
+// expandProperties expands a top-level name-to-property map whose entries are referenceable under the path parts.
+func (r *Resource) expandProperties(properties map[string]*Property, path ...string) error {
+	for _, propertyName := range sortedPropertyNames(properties) {
+		ref := NewReference(path[0], append(path[1:len(path):len(path)], propertyName)...)
+
+		if err := r.expandReference(ref, propertyName, properties[propertyName], false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// expandReference expands the target of a Reference in place, once for targets in this resource schema.
+// The alias flag records whether the Reference was found directly on the target currently being expanded.
+func (r *Resource) expandReference(ref Reference, name string, target *Property, alias bool) error {
+	state := r.expandState
+
+	if _, ok := state.expanded[ref]; ok {
+		return nil
+	}
+
+	if err := state.push(ref, target, alias); err != nil {
+		return err
+	}
+
+	err := r.ResolveProperties(map[string]*Property{name: target})
+
+	state.pop()
+
+	if err != nil {
+		return err
+	}
+
+	// References to other documents resolve to a new copy each time.
+	if ref.IsLocal() {
+		state.expanded[ref] = struct{}{}
+	}
+
 	return nil
 }
 
@@ -35,7 +165,9 @@ func (r *Resource) Expand() error {
 // In theory unresolved form nested properties are not allowed but in practice they do occur,
 // so support arbitrarily deeply nested references.
 func (r *Resource) ResolveProperties(properties map[string]*Property) error {
-	for propertyName, property := range properties {
+	for _, propertyName := range sortedPropertyNames(properties) {
+		property := properties[propertyName]
+
 		// For example:
 		//
 		// "Configuration": {
@@ -51,7 +183,7 @@ func (r *Resource) ResolveProperties(properties map[string]*Property) error {
 			continue
 		}
 
-		switch property.Type.String() {
+		switch property.Type.Primary() {
 		case PropertyTypeArray:
 			// For example:
 			//
@@ -71,7 +203,7 @@ func (r *Resource) ResolveProperties(properties map[string]*Property) error {
 				continue
 			}
 
-			if property.Items.Type.String() == PropertyTypeObject {
+			if property.Items.Type.Primary() == PropertyTypeObject {
 				// For example:
 				//
 				// "Tags": {
@@ -168,42 +300,92 @@ func (r *Resource) ResolveProperties(properties map[string]*Property) error {
 
 // ResolveProperty resolves any Reference (JSON Pointer) in a Property.
 // Returns whether a Reference was resolved.
+//
+// During Expand, and for References to other documents, the Reference target is itself expanded before
+// being inlined and a Reference to a target which is already being expanded sets RecursiveRef instead.
 func (r *Resource) ResolveProperty(property *Property) (bool, error) {
-	if property != nil && property.Ref != nil {
-		defaultValue := property.Default
-		description := property.Description
-		ref := property.Ref
-		resolution, err := r.ResolveReference(*ref)
+	if property == nil || property.Ref == nil {
+		return false, nil
+	}
+
+	// References to other documents resolve to a copy whose own References
+	// have not yet been expanded.
+	if r.expandState == nil && !property.Ref.IsLocal() {
+		r.expandState = newExpandState()
+		defer func() { r.expandState = nil }()
+	}
+
+	defaultValue := property.Default
+	description := property.Description
+	ref := *property.Ref
+	sourcePosition := property.SourcePosition
+	state := r.expandState
+	key := rebaseReference(ref, r.referenceBase)
+
+	if state != nil {
+		recursive, err := state.recursive(key, property)
 
 		if err != nil {
 			return false, err
 		}
 
-		err = r.UnwrapOneOfProperties(resolution)
+		if recursive {
+			property.RecursiveRef = &ref
+			property.Ref = nil
 
-		if err != nil {
-			return false, err
+			return true, nil
 		}
+	}
+
+	resolution, err := r.ResolveReference(ref)
+
+	if err != nil {
+		return false, err
+	}
 
-		*property = *resolution
+	err = r.UnwrapOneOfProperties(resolution)
 
-		// Ensure that any default value is not lost.
-		if defaultValue != nil {
-			property.Default = defaultValue
+	if err != nil {
+		return false, err
+	}
+
+	if state != nil {
+		err = r.expandReference(key, ref.String(), resolution, state.isRoot(property))
+
+		if err != nil {
+			return false, err
 		}
 
-		// Prefer any description from the unresolved property.
-		if description != nil && *description != "" {
-			property.Description = description
+		if state.independent {
+			resolution = cloneProperty(resolution, nil)
 		}
+	}
+
+	*property = *resolution
 
-		return true, nil
+	// Record where the content came from, including any chain of References followed by the resolution.
+	property.ResolvedRefs = append([]Reference{ref}, resolution.ResolvedRefs...)
+
+	// Ensure that any default value is not lost.
+	if defaultValue != nil {
+		property.Default = defaultValue
 	}
 
-	return false, nil
+	// Prefer any description from the unresolved property.
+	if description != nil && *description != "" {
+		property.Description = description
+	}
+
+	// Keep the location of the unresolved property.
+	if sourcePosition != nil {
+		property.SourcePosition = sourcePosition
+	}
+
+	return true, nil
 }
 
 // UnwrapOneOfProperties unwraps a set of properties nested in a oneOf element.
+// The original oneOf subschemas are kept in UnwrappedOneOf.
 func (r *Resource) UnwrapOneOfProperties(property *Property) error {
 	if len(property.Properties) == 0 && len(property.PatternProperties) == 0 && len(property.OneOf) > 0 {
 		// For example:
@@ -226,17 +408,98 @@ func (r *Resource) UnwrapOneOfProperties(property *Property) error {
 		// }
 		unwrappedProperties := make(map[string]*Property)
 
+		var unwrappedPropertiesOrder []string
+
 		for _, propertySubschema := range property.OneOf {
 			for propertyName, property := range propertySubschema.Properties {
 				unwrappedProperties[propertyName] = property
 			}
+
+			unwrappedPropertiesOrder = unionStrings(unwrappedPropertiesOrder, propertySubschema.OrderedPropertyNames())
 		}
 
+		property.UnwrappedOneOf = property.OneOf
 		property.OneOf = nil
 		property.Properties = unwrappedProperties
+		property.PropertiesOrder = unwrappedPropertiesOrder
 		typ := Type(PropertyTypeObject)
 		property.Type = &typ
 	}
 
 	return nil
 }
+
+// expandState tracks the References being expanded.
+type expandState struct {
+	expanded    map[Reference]struct{}
+	frames      []expandFrame
+	independent bool
+	maxDepth    int
+}
+
+// expandFrame is a Reference whose target is being expanded.
+type expandFrame struct {
+	alias bool
+	ref   Reference
+	root  *Property
+}
+
+func newExpandState() *expandState {
+	return &expandState{
+		expanded: make(map[Reference]struct{}),
+	}
+}
+
+// isRoot returns true if the property is the target currently being expanded.
+func (s *expandState) isRoot(property *Property) bool {
+	return len(s.frames) > 0 && s.frames[len(s.frames)-1].root == property
+}
+
+// pop stops tracking the most recently pushed Reference.
+func (s *expandState) pop() {
+	s.frames = s.frames[:len(s.frames)-1]
+}
+
+// push starts tracking a Reference whose target is being expanded.
+func (s *expandState) push(ref Reference, root *Property, alias bool) error {
+	if s.maxDepth > 0 && len(s.frames) >= s.maxDepth {
+		return fmt.Errorf("maximum expansion depth (%d) exceeded expanding %s", s.maxDepth, ref)
+	}
+
+	s.frames = append(s.frames, expandFrame{
+		alias: alias,
+		ref:   ref,
+		root:  root,
+	})
+
+	return nil
+}
+
+// recursive returns true if the Reference target is already being expanded.
+// A cycle of References with no content in between, e.g. a definition that is only a Reference to itself,
+// can never be expanded and returns an error.
+func (s *expandState) recursive(ref Reference, property *Property) (bool, error) {
+	for i, frame := range s.frames {
+		if frame.ref != ref {
+			continue
+		}
+
+		if !s.isRoot(property) {
+			return true, nil
+		}
+
+		refs := []string{frame.ref.String()}
+
+		for _, frame := range s.frames[i+1:] {
+			if !frame.alias {
+				return true, nil
+			}
+
+			refs = append(refs, frame.ref.String())
+		}
+
+		return false, fmt.Errorf("reference cycle detected: %s -> %s", strings.Join(refs, " -> "), ref)
+	}
+
+	return false, nil
+}
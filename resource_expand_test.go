@@ -4,6 +4,8 @@
 package cfschema_test
 
 import (
+	"encoding/json"
+	"reflect"
 	"testing"
 
 	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
@@ -345,3 +347,251 @@ func TestResourceExpand_SecondLevelNestedDefinition(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceExpand_Recursive(t *testing.T) {
+	testCases := []struct {
+		TestDescription      string
+		Schema               string
+		Options              []cfschema.ExpandOption
+		ExpectError          bool
+		PropertyPath         []string
+		ExpectedRecursiveRef cfschema.Reference
+	}{
+		{
+			TestDescription:      "self reference",
+			Schema:               `{"typeName": "Initech::TPS::Report", "definitions": {"Node": {"type": "object", "properties": {"Value": {"type": "string"}, "Children": {"type": "array", "items": {"$ref": "#/definitions/Node"}}}}}, "properties": {"Root": {"$ref": "#/definitions/Node"}}}`,
+			PropertyPath:         []string{"Root", "Children", "*"},
+			ExpectedRecursiveRef: "#/definitions/Node",
+		},
+		{
+			TestDescription:      "mutual reference",
+			Schema:               `{"typeName": "Initech::TPS::Report", "definitions": {"A": {"type": "object", "properties": {"B": {"$ref": "#/definitions/B"}}}, "B": {"type": "object", "properties": {"A": {"$ref": "#/definitions/A"}}}}, "properties": {"Root": {"$ref": "#/definitions/A"}}}`,
+			PropertyPath:         []string{"Root", "B", "A"},
+			ExpectedRecursiveRef: "#/definitions/A",
+		},
+		{
+			TestDescription:      "property reference",
+			Schema:               `{"typeName": "Initech::TPS::Report", "properties": {"Root": {"type": "object", "properties": {"Parent": {"$ref": "#/properties/Root"}}}}}`,
+			PropertyPath:         []string{"Root", "Parent"},
+			ExpectedRecursiveRef: "#/properties/Root",
+		},
+		{
+			TestDescription: "reference cycle",
+			Schema:          `{"typeName": "Initech::TPS::Report", "definitions": {"A": {"$ref": "#/definitions/B"}, "B": {"$ref": "#/definitions/A"}}, "properties": {"Root": {"$ref": "#/definitions/A"}}}`,
+			ExpectError:     true,
+		},
+		{
+			TestDescription: "within max depth",
+			Schema:          `{"typeName": "Initech::TPS::Report", "definitions": {"A": {"type": "object", "properties": {"B": {"$ref": "#/definitions/B"}}}, "B": {"type": "object", "properties": {"C": {"$ref": "#/definitions/C"}}}, "C": {"type": "string"}}, "properties": {"Root": {"$ref": "#/definitions/A"}}}`,
+			Options:         []cfschema.ExpandOption{cfschema.WithExpandMaxDepth(3)},
+			PropertyPath:    []string{"Root", "B", "C"},
+		},
+		{
+			TestDescription: "exceeds max depth",
+			Schema:          `{"typeName": "Initech::TPS::Report", "definitions": {"A": {"type": "object", "properties": {"B": {"$ref": "#/definitions/B"}}}, "B": {"type": "object", "properties": {"C": {"$ref": "#/definitions/C"}}}, "C": {"type": "string"}}, "properties": {"Root": {"$ref": "#/definitions/A"}}}`,
+			Options:         []cfschema.ExpandOption{cfschema.WithExpandMaxDepth(2)},
+			ExpectError:     true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			var resource cfschema.Resource
+
+			if err := json.Unmarshal([]byte(testCase.Schema), &resource); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			err := resource.Expand(testCase.Options...)
+
+			if err != nil && !testCase.ExpectError {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err == nil && testCase.ExpectError {
+				t.Fatal("expected error, got none")
+			}
+
+			if err != nil {
+				return
+			}
+
+			// A cyclic expansion cannot be marshalled.
+			if _, err := json.Marshal(resource); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			// LookupPointer follows RecursiveRef, so navigate the expanded properties directly.
+			property := resource.Properties[testCase.PropertyPath[0]]
+
+			for _, name := range testCase.PropertyPath[1:] {
+				if property == nil {
+					break
+				}
+
+				if name == cfschema.PropertyJsonPointerWildcard {
+					property = property.Items
+				} else {
+					property = property.Properties[name]
+				}
+			}
+
+			if property == nil {
+				t.Fatalf("expected property at %v, got none", testCase.PropertyPath)
+			}
+
+			if property.Ref != nil {
+				t.Errorf("expected no Ref, got: %s", property.Ref)
+			}
+
+			var actual cfschema.Reference
+
+			if property.RecursiveRef != nil {
+				actual = *property.RecursiveRef
+			}
+
+			if expected := testCase.ExpectedRecursiveRef; actual != expected {
+				t.Errorf("expected RecursiveRef (%s), got: %s", expected, actual)
+			}
+
+			if testCase.ExpectedRecursiveRef == "" {
+				return
+			}
+
+			b, err := json.Marshal(property)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if actual, expected := string(b), `{"$ref":"`+string(testCase.ExpectedRecursiveRef)+`"}`; actual != expected {
+				t.Errorf("expected marshalled (%s), got: %s", expected, actual)
+			}
+		})
+	}
+}
+
+func TestResourceExpanded(t *testing.T) {
+	resource := loadAndValidateResourceSchema(t, "provider.definition.schema.v1.json", "initech.tps.report.v1.json")
+
+	expected, err := json.Marshal(resource)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expanded, err := resource.Expanded()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	actual, err := json.Marshal(resource)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if string(actual) != string(expected) {
+		t.Errorf("expected original to be unmodified, got: %s", actual)
+	}
+
+	if ref := resource.Properties["Memo"].Ref; ref == nil {
+		t.Error("expected original Memo Ref, got none")
+	}
+
+	memo := expanded.Properties["Memo"]
+	secondCopyOfMemo := expanded.Properties["SecondCopyOfMemo"]
+
+	if memo.Ref != nil || secondCopyOfMemo.Ref != nil {
+		t.Fatal("expected expanded Memo properties")
+	}
+
+	typ := cfschema.Type(cfschema.PropertyTypeInteger)
+	memo.Properties["Heading"].Type = &typ
+	memo.Properties["Footer"] = &cfschema.Property{}
+
+	for _, property := range []*cfschema.Property{secondCopyOfMemo, expanded.Definitions["Memo"]} {
+		if actual, expected := property.Properties["Heading"].Type.String(), cfschema.PropertyTypeString; actual != expected {
+			t.Errorf("expected Heading type (%s), got: %s", expected, actual)
+		}
+
+		if _, ok := property.Properties["Footer"]; ok {
+			t.Error("expected no Footer property")
+		}
+	}
+}
+
+func TestResourceExpand_ResolvedRefs(t *testing.T) {
+	schema := `{
+		"typeName": "Initech::TPS::Report",
+		"definitions": {
+			"Alias": {"$ref": "#/definitions/Memo"},
+			"Memo": {"type": "object", "properties": {"Heading": {"type": "string"}, "Date": {"$ref": "#/definitions/Date"}}},
+			"Date": {"type": "string"}
+		},
+		"properties": {
+			"Memo": {"$ref": "#/definitions/Memo"},
+			"Memos": {"type": "array", "items": {"$ref": "#/definitions/Alias"}},
+			"Title": {"type": "string"}
+		}
+	}`
+
+	testCases := []struct {
+		TestDescription      string
+		PropertyPath         []string
+		ExpectedResolvedRefs []cfschema.Reference
+	}{
+		{
+			TestDescription:      "definition reference",
+			PropertyPath:         []string{"Memo"},
+			ExpectedResolvedRefs: []cfschema.Reference{"#/definitions/Memo"},
+		},
+		{
+			TestDescription:      "nested definition reference",
+			PropertyPath:         []string{"Memo", "Date"},
+			ExpectedResolvedRefs: []cfschema.Reference{"#/definitions/Date"},
+		},
+		{
+			TestDescription:      "reference chain",
+			PropertyPath:         []string{"Memos", "*"},
+			ExpectedResolvedRefs: []cfschema.Reference{"#/definitions/Alias", "#/definitions/Memo"},
+		},
+		{
+			TestDescription: "no reference",
+			PropertyPath:    []string{"Title"},
+		},
+		{
+			TestDescription: "no nested reference",
+			PropertyPath:    []string{"Memo", "Heading"},
+		},
+	}
+
+	var resource cfschema.Resource
+
+	if err := json.Unmarshal([]byte(schema), &resource); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := resource.Expand(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			property, _, err := resource.LookupPointer(cfschema.NewPropertyJsonPointer(testCase.PropertyPath...))
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if actual, expected := property.ResolvedRefs, testCase.ExpectedResolvedRefs; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("expected ResolvedRefs (%v), got: %v", expected, actual)
+			}
+		})
+	}
+}
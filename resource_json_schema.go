@@ -6,6 +6,7 @@ package cfschema
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 )
 
 // ResourceJsonSchema represents the resource schema.
@@ -14,6 +15,10 @@ type ResourceJsonSchema struct {
 }
 
 // Resource parses the JSON Schema and returns Resource or an error.
+//
+// If the JSON Schema was loaded from a file path, References to other documents
+// are resolved relative to the directory of that file. The SourcePosition of each
+// Property and Handler is set to its location in the JSON Schema document.
 func (s *ResourceJsonSchema) Resource() (*Resource, error) {
 	if s == nil {
 		return nil, nil
@@ -27,6 +32,12 @@ func (s *ResourceJsonSchema) Resource() (*Resource, error) {
 		return nil, fmt.Errorf("parsing JSON Schema into Resource: %w", err)
 	}
 
+	result.setSourcePositions(newSourcePositions(s.source, s.path))
+
+	if s.path != "" {
+		result.SetReferenceLoader(NewDirectoryReferenceLoader(filepath.Dir(s.path)))
+	}
+
 	return &result, nil
 }
 
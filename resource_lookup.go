@@ -0,0 +1,190 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LookupPointer returns the Property at the PropertyJsonPointer and the chain of parent properties,
+// outermost first.
+//
+// Properties, Items and PatternProperties are traversed and any References are resolved without modifying
+// the Resource, so this works on both expanded and unexpanded Resources. Array items are traversed either via
+// the * wildcard or implicitly when a property name follows an array, in which case both the array property
+// and its items are included in the parents. Pattern properties are traversed via the * wildcard, the pattern
+// itself or any name matching the pattern. Properties nested in allOf, anyOf and oneOf subschemas are found
+// when the object has no properties of its own.
+func (r *Resource) LookupPointer(ptr PropertyJsonPointer) (*Property, []*Property, error) {
+	if r == nil {
+		return nil, nil, nil
+	}
+
+	if !strings.HasPrefix(string(ptr), PropertiesJsonPointerPrefix+JsonPointerReferenceTokenSeparator) {
+		return nil, nil, fmt.Errorf("invalid PropertyJsonPointer (%s): expected %s prefix", ptr, PropertiesJsonPointerPrefix)
+	}
+
+	path := ptr.Path()
+
+	var parents []*Property
+
+	property, ok := r.Properties[path[0]]
+
+	if !ok || property == nil {
+		return nil, nil, fmt.Errorf("%s not found", ptr)
+	}
+
+	current := r.resolvedProperty(property)
+
+	if current == nil {
+		return nil, nil, fmt.Errorf("%s: unable to resolve %s", ptr, property.Ref)
+	}
+
+	for _, segment := range path[1:] {
+		parents = append(parents, current)
+
+		if current.Items != nil {
+			items := r.resolvedProperty(current.Items)
+
+			if items == nil {
+				return nil, nil, fmt.Errorf("%s: unable to resolve %s", ptr, current.Items.Ref)
+			}
+
+			current = items
+
+			if segment == PropertyJsonPointerWildcard {
+				continue
+			}
+
+			parents = append(parents, current)
+		}
+
+		property, ok := lookupPropertyName(current, segment)
+
+		if !ok || property == nil {
+			return nil, nil, fmt.Errorf("%s not found", ptr)
+		}
+
+		if current = r.resolvedProperty(property); current == nil {
+			return nil, nil, fmt.Errorf("%s: unable to resolve %s", ptr, property.Ref)
+		}
+	}
+
+	return current, parents, nil
+}
+
+// lookupPropertyName returns the named child of an object Property.
+func lookupPropertyName(property *Property, name string) (*Property, bool) {
+	if child, ok := subschemaProperties(property)[name]; ok {
+		return child, true
+	}
+
+	if len(property.PatternProperties) == 0 {
+		return nil, false
+	}
+
+	if child, ok := property.PatternProperties[name]; ok {
+		return child, true
+	}
+
+	patterns := make([]string, 0, len(property.PatternProperties))
+
+	for pattern := range property.PatternProperties {
+		patterns = append(patterns, pattern)
+	}
+
+	sort.Strings(patterns)
+
+	for _, pattern := range patterns {
+		if name == PropertyJsonPointerWildcard {
+			return property.PatternProperties[pattern], true
+		}
+
+		if re, err := regexp.Compile(pattern); err == nil && re.MatchString(name) {
+			return property.PatternProperties[pattern], true
+		}
+	}
+
+	return nil, false
+}
+
+// resolvedProperty follows any Reference chain from the Property without modifying the Resource,
+// including the RecursiveRef kept by Expand in place of a recursive Reference.
+// Returns nil if a Reference cannot be resolved or the chain is cyclic.
+func (r *Resource) resolvedProperty(property *Property) *Property {
+	property, _ = r.resolvedPropertyReference(property)
+
+	return property
+}
+
+// resolvedPropertyReference follows any Reference chain from the Property as resolvedProperty,
+// also returning the last Reference followed, if any.
+func (r *Resource) resolvedPropertyReference(property *Property) (*Property, *Reference) {
+	var last *Reference
+
+	visited := make(map[Reference]struct{})
+
+	for property != nil && propertyReference(property) != nil {
+		ref := propertyReference(property)
+
+		if _, ok := visited[*ref]; ok {
+			return nil, nil
+		}
+
+		visited[*ref] = struct{}{}
+
+		resolution, err := r.ResolveReference(*ref)
+
+		if err != nil {
+			return nil, nil
+		}
+
+		last = ref
+		property = resolution
+	}
+
+	return property, last
+}
+
+// propertyReference returns the Ref of the Property, or else the RecursiveRef kept by Expand.
+func propertyReference(property *Property) *Reference {
+	if property.Ref != nil {
+		return property.Ref
+	}
+
+	return property.RecursiveRef
+}
+
+// subschemaProperties returns the Property properties, including those nested in subschemas.
+func subschemaProperties(property *Property) map[string]*Property {
+	if len(property.Properties) > 0 {
+		return property.Properties
+	}
+
+	properties := make(map[string]*Property)
+
+	for _, subschemas := range [][]*PropertySubschema{property.AllOf, property.AnyOf, property.OneOf} {
+		for _, subschema := range subschemas {
+			if subschema == nil {
+				continue
+			}
+
+			for name, property := range subschema.Properties {
+				properties[name] = property
+			}
+		}
+	}
+
+	return properties
+}
+
+// hasPointer returns true if the PropertyJsonPointer resolves to a property.
+func (r *Resource) hasPointer(ptr PropertyJsonPointer) bool {
+	_, _, err := r.LookupPointer(ptr)
+
+	return err == nil
+}
@@ -0,0 +1,135 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestResourceLookupPointer(t *testing.T) {
+	testCases := []struct {
+		TestDescription    string
+		ResourceSchemaPath string
+		Expand             bool
+		Pointer            cfschema.PropertyJsonPointer
+		ExpectError        bool
+		ExpectedType       cfschema.Type
+		ExpectedParents    int
+	}{
+		{
+			TestDescription:    "invalid prefix",
+			ResourceSchemaPath: "AWS_ECS_Cluster.json",
+			Pointer:            "/definitions/ClusterConfiguration",
+			ExpectError:        true,
+		},
+		{
+			TestDescription:    "not found",
+			ResourceSchemaPath: "AWS_ECS_Cluster.json",
+			Pointer:            "/properties/Configuration/Missing",
+			ExpectError:        true,
+		},
+		{
+			TestDescription:    "top level",
+			ResourceSchemaPath: "AWS_ECS_Cluster.json",
+			Pointer:            "/properties/ClusterName",
+			ExpectedType:       cfschema.PropertyTypeString,
+		},
+		{
+			TestDescription:    "nested references",
+			ResourceSchemaPath: "AWS_ECS_Cluster.json",
+			Pointer:            "/properties/Configuration/ExecuteCommandConfiguration/LogConfiguration/CloudWatchEncryptionEnabled",
+			ExpectedType:       cfschema.PropertyTypeBoolean,
+			ExpectedParents:    3,
+		},
+		{
+			TestDescription:    "nested expanded",
+			ResourceSchemaPath: "AWS_ECS_Cluster.json",
+			Expand:             true,
+			Pointer:            "/properties/Configuration/ExecuteCommandConfiguration/LogConfiguration/CloudWatchEncryptionEnabled",
+			ExpectedType:       cfschema.PropertyTypeBoolean,
+			ExpectedParents:    3,
+		},
+		{
+			TestDescription:    "array items implicit",
+			ResourceSchemaPath: "AWS_ECS_Cluster.json",
+			Pointer:            "/properties/DefaultCapacityProviderStrategy/Weight",
+			ExpectedType:       cfschema.PropertyTypeInteger,
+			ExpectedParents:    2,
+		},
+		{
+			TestDescription:    "array items wildcard",
+			ResourceSchemaPath: "AWS_ECS_Cluster.json",
+			Pointer:            "/properties/DefaultCapacityProviderStrategy/*/Weight",
+			ExpectedType:       cfschema.PropertyTypeInteger,
+			ExpectedParents:    2,
+		},
+		{
+			TestDescription:    "pattern properties",
+			ResourceSchemaPath: "AWS_GreengrassV2_ComponentVersion.json",
+			Pointer:            "/properties/LambdaFunction/ComponentDependencies/*/VersionRequirement",
+			ExpectedType:       cfschema.PropertyTypeString,
+			ExpectedParents:    3,
+		},
+		{
+			TestDescription:    "pattern properties name",
+			ResourceSchemaPath: "AWS_GreengrassV2_ComponentVersion.json",
+			Pointer:            "/properties/LambdaFunction/ComponentDependencies/aws.greengrass.Nucleus/VersionRequirement",
+			ExpectedType:       cfschema.PropertyTypeString,
+			ExpectedParents:    3,
+		},
+		{
+			TestDescription:    "oneOf",
+			ResourceSchemaPath: "AWS_S3ObjectLambda_AccessPoint.json",
+			Pointer:            "/properties/ObjectLambdaConfiguration/TransformationConfigurations/ContentTransformation/AwsLambda/FunctionArn",
+			ExpectedType:       cfschema.PropertyTypeString,
+			ExpectedParents:    5,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			resource := loadAndValidateResourceSchema(t, "provider.definition.schema.v1.json", testCase.ResourceSchemaPath)
+
+			if testCase.Expand {
+				if err := resource.Expand(); err != nil {
+					t.Fatalf("unexpected Expand() error: %s", err)
+				}
+			}
+
+			property, parents, err := resource.LookupPointer(testCase.Pointer)
+
+			if err != nil && !testCase.ExpectError {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err == nil && testCase.ExpectError {
+				t.Fatal("expected error, got none")
+			}
+
+			if err != nil {
+				return
+			}
+
+			if actual, expected := property.Type.String(), testCase.ExpectedType.String(); actual != expected {
+				t.Errorf("expected type (%s), got: %s", expected, actual)
+			}
+
+			if actual, expected := len(parents), testCase.ExpectedParents; actual != expected {
+				t.Errorf("expected %d parents, got: %d", expected, actual)
+			}
+
+			for i, parent := range parents {
+				if parent == nil {
+					t.Errorf("unexpected nil parent at %d", i)
+				} else if parent.Ref != nil {
+					t.Errorf("expected resolved parent at %d, got: %s", i, parent.Ref)
+				}
+			}
+		})
+	}
+}
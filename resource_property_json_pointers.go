@@ -0,0 +1,75 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"fmt"
+)
+
+// DanglingPropertyJsonPointer represents a PropertyJsonPointer that does not resolve to a property.
+type DanglingPropertyJsonPointer struct {
+	// Location is the JSON Pointer to the list entry in the resource schema, e.g. /readOnlyProperties/0.
+	Location string
+
+	// Pointer is the dangling PropertyJsonPointer.
+	Pointer PropertyJsonPointer
+}
+
+// String returns a string representation of DanglingPropertyJsonPointer.
+func (p *DanglingPropertyJsonPointer) String() string {
+	if p == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s: %s not found", p.Location, p.Pointer)
+}
+
+// DanglingPropertyJsonPointers returns every PropertyJsonPointer in the Resource that does not resolve to
+// a property in the expanded schema.
+//
+// This checks additionalIdentifiers, conditionalCreateOnlyProperties, createOnlyProperties, deprecatedProperties,
+// nonPublicProperties, primaryIdentifier, readOnlyProperties, writeOnlyProperties and tagging tagProperty.
+// References are resolved without modifying the Resource, so this can be called before or after Expand.
+// Array items are traversed either via the * wildcard or implicitly by property name.
+func (r *Resource) DanglingPropertyJsonPointers() []*DanglingPropertyJsonPointer {
+	if r == nil {
+		return nil
+	}
+
+	var result []*DanglingPropertyJsonPointer
+
+	check := func(location string, ptrs PropertyJsonPointers) {
+		for i, ptr := range ptrs {
+			if !r.hasPointer(ptr) {
+				result = append(result, &DanglingPropertyJsonPointer{
+					Location: fmt.Sprintf("%s/%d", location, i),
+					Pointer:  ptr,
+				})
+			}
+		}
+	}
+
+	for i, ptrs := range r.AdditionalIdentifiers {
+		check(fmt.Sprintf("/additionalIdentifiers/%d", i), ptrs)
+	}
+
+	check("/conditionalCreateOnlyProperties", r.ConditionalCreateOnlyProperties)
+	check("/createOnlyProperties", r.CreateOnlyProperties)
+	check("/deprecatedProperties", r.DeprecatedProperties)
+	check("/nonPublicProperties", r.NonPublicProperties)
+	check("/primaryIdentifier", r.PrimaryIdentifier)
+	check("/readOnlyProperties", r.ReadOnlyProperties)
+	check("/writeOnlyProperties", r.WriteOnlyProperties)
+
+	if r.Tagging != nil && r.Tagging.TagProperty != nil {
+		if ptr := *r.Tagging.TagProperty; !r.hasPointer(ptr) {
+			result = append(result, &DanglingPropertyJsonPointer{
+				Location: "/tagging/tagProperty",
+				Pointer:  ptr,
+			})
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,158 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestResourceDanglingPropertyJsonPointers(t *testing.T) {
+	resource := &cfschema.Resource{
+		AdditionalIdentifiers: []cfschema.PropertyJsonPointers{
+			{"/properties/Name"},
+			{"/properties/Alias"},
+		},
+		CreateOnlyProperties: cfschema.PropertyJsonPointers{"/properties/Name", "/properties/Rules/*/Action/Type"},
+		Definitions: map[string]*cfschema.Property{
+			"Action": {
+				Type: testType(cfschema.PropertyTypeObject),
+				Properties: map[string]*cfschema.Property{
+					"Type": {Type: testType(cfschema.PropertyTypeString)},
+				},
+			},
+			"Rule": {
+				Type: testType(cfschema.PropertyTypeObject),
+				Properties: map[string]*cfschema.Property{
+					"Action": {Ref: testReference("#/definitions/Action")},
+				},
+			},
+		},
+		DeprecatedProperties: cfschema.PropertyJsonPointers{"/properties/Rules/Action/Name"},
+		PrimaryIdentifier:    cfschema.PropertyJsonPointers{"/properties/Arn"},
+		Properties: map[string]*cfschema.Property{
+			"Name": {Type: testType(cfschema.PropertyTypeString)},
+			"Rules": {
+				Type:  testType(cfschema.PropertyTypeArray),
+				Items: &cfschema.Property{Ref: testReference("#/definitions/Rule")},
+			},
+		},
+		ReadOnlyProperties:  cfschema.PropertyJsonPointers{"/properties/Arn", "/properties/Rules/*/Id"},
+		WriteOnlyProperties: cfschema.PropertyJsonPointers{"/properties/Rules/Action"},
+		Tagging: &cfschema.Tagging{
+			TagProperty: testPropertyJsonPointer("/properties/Tags"),
+		},
+	}
+
+	expected := []string{
+		"/additionalIdentifiers/1/0: /properties/Alias not found",
+		"/deprecatedProperties/0: /properties/Rules/Action/Name not found",
+		"/primaryIdentifier/0: /properties/Arn not found",
+		"/readOnlyProperties/0: /properties/Arn not found",
+		"/readOnlyProperties/1: /properties/Rules/*/Id not found",
+		"/tagging/tagProperty: /properties/Tags not found",
+	}
+
+	actual := danglingPropertyJsonPointerStrings(resource)
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected (%v), got: %v", expected, actual)
+	}
+}
+
+func TestResourceDanglingPropertyJsonPointers_ExpandedRecursive(t *testing.T) {
+	var resource cfschema.Resource
+
+	if err := json.Unmarshal([]byte(`{
+		"typeName": "Initech::TPS::Report",
+		"definitions": {
+			"Statement": {
+				"type": "object",
+				"properties": {
+					"Name": {"type": "string"},
+					"And": {"type": "array", "items": {"$ref": "#/definitions/Statement"}}
+				}
+			}
+		},
+		"properties": {
+			"Statement": {"$ref": "#/definitions/Statement"}
+		},
+		"readOnlyProperties": ["/properties/Statement/Name", "/properties/Statement/And/And/Name", "/properties/Statement/And/Missing"]
+	}`), &resource); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{
+		"/readOnlyProperties/2: /properties/Statement/And/Missing not found",
+	}
+
+	for _, expand := range []bool{false, true} {
+		if expand {
+			if err := resource.Expand(); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		}
+
+		if actual := danglingPropertyJsonPointerStrings(&resource); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("expected (%v) with expand (%t), got: %v", expected, expand, actual)
+		}
+
+		property, _, err := resource.LookupPointer("/properties/Statement/And/And/And/Name")
+
+		if err != nil {
+			t.Fatalf("unexpected error with expand (%t): %s", expand, err)
+		}
+
+		if actual, expected := property.Type.String(), cfschema.PropertyTypeString; actual != expected {
+			t.Errorf("expected type (%s) with expand (%t), got: %s", expected, expand, actual)
+		}
+	}
+}
+
+func TestResourceDanglingPropertyJsonPointers_Testdata(t *testing.T) {
+	// Upstream schemas with known dangling pointers.
+	expected := map[string][]string{
+		"AWS_GameLift_Fleet.json": {"/createOnlyProperties/11: /properties/CertificateType not found"},
+	}
+
+	paths, err := filepath.Glob(filepath.Join("testdata", "AWS_*.json"))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, path := range paths {
+		path := filepath.Base(path)
+
+		t.Run(path, func(t *testing.T) {
+			resource := loadAndValidateResourceSchema(t, "provider.definition.schema.v1.json", path)
+
+			if actual, expected := danglingPropertyJsonPointerStrings(resource), expected[path]; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("expected dangling pointers before Expand (%v), got: %v", expected, actual)
+			}
+
+			if err := resource.Expand(); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if actual, expected := danglingPropertyJsonPointerStrings(resource), expected[path]; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("expected dangling pointers after Expand (%v), got: %v", expected, actual)
+			}
+		})
+	}
+}
+
+func danglingPropertyJsonPointerStrings(resource *cfschema.Resource) []string {
+	var result []string
+
+	for _, dangling := range resource.DanglingPropertyJsonPointers() {
+		result = append(result, dangling.String())
+	}
+
+	return result
+}
@@ -0,0 +1,188 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"sync"
+)
+
+// referenceDocument is a document loaded by a ReferenceLoader.
+type referenceDocument struct {
+	property *Property
+	resource *Resource
+}
+
+// referenceDocumentCache caches the documents loaded by a ReferenceLoader. It is shared by a Resource,
+// its copies and the Resources of the documents it loads, so is safe for concurrent use.
+type referenceDocumentCache struct {
+	documents map[string]*referenceDocument
+	mu        sync.Mutex
+}
+
+// document returns the cached document at the loader path, loading it if necessary.
+func (c *referenceDocumentCache) document(documentPath string, load func(string) (*referenceDocument, error)) (*referenceDocument, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if document, ok := c.documents[documentPath]; ok {
+		return document, nil
+	}
+
+	document, err := load(documentPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.documents[documentPath] = document
+
+	return document, nil
+}
+
+// SetReferenceLoader sets the ReferenceLoader used to resolve References to other documents.
+//
+// Resources returned by ResourceJsonSchema.Resource for a schema loaded from a file path are
+// automatically configured to load documents relative to the directory of that file.
+func (r *Resource) SetReferenceLoader(loader ReferenceLoader) {
+	if r == nil {
+		return
+	}
+
+	r.referenceBase = ""
+	r.referenceDocuments = &referenceDocumentCache{documents: make(map[string]*referenceDocument)}
+	r.referenceLoader = loader
+}
+
+// resolveDocumentReference resolves a Reference to another document.
+func (r *Resource) resolveDocumentReference(ref Reference) (*Property, error) {
+	if r.referenceLoader == nil {
+		return nil, fmt.Errorf("resolving %s: no ReferenceLoader configured", ref)
+	}
+
+	documentPath := r.referenceDocumentPath(ref.Document())
+
+	document, err := r.referenceDocuments.document(documentPath, r.loadReferenceDocument)
+
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s: %w", ref, err)
+	}
+
+	property := document.property
+
+	if _, fragment := ref.split(); fragment != "" {
+		property, err = document.resource.ResolveReference(Reference(ReferenceAnchor + fragment))
+
+		if err != nil {
+			return nil, fmt.Errorf("resolving %s: %w", ref, err)
+		}
+	}
+
+	return cloneProperty(property, func(ref Reference) Reference {
+		return rebaseReference(ref, documentPath)
+	}), nil
+}
+
+// loadReferenceDocument loads and parses a referenced document.
+func (r *Resource) loadReferenceDocument(documentPath string) (*referenceDocument, error) {
+	b, err := r.referenceLoader.Load(documentPath)
+
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %w", documentPath, err)
+	}
+
+	var property Property
+
+	if err := json.Unmarshal(b, &property); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", documentPath, err)
+	}
+
+	var resource Resource
+
+	if err := json.Unmarshal(b, &resource); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", documentPath, err)
+	}
+
+	resource.referenceBase = documentPath
+	resource.referenceDocuments = r.referenceDocuments
+	resource.referenceLoader = r.referenceLoader
+
+	return &referenceDocument{
+		property: &property,
+		resource: &resource,
+	}, nil
+}
+
+// referenceDocumentPath returns the loader path of a referenced document relative to this Resource's document.
+func (r *Resource) referenceDocumentPath(document string) string {
+	return rebaseDocument(document, r.referenceBase)
+}
+
+// resolveRemoteReference resolves a Reference into an inlined remote schema, e.g. #/remote/schema0/definitions/Foo.
+func (r *Resource) resolveRemoteReference(ref Reference, path []string) (*Property, error) {
+	remote, ok := r.Remote[path[1]]
+
+	if !ok || remote == nil {
+		return nil, fmt.Errorf("%s/%s not found", ReferenceTypeRemote, path[1])
+	}
+
+	if len(path) < 4 {
+		return nil, fmt.Errorf("invalid Reference (%s). Expected at least 4 parts, got %d", ref, len(path))
+	}
+
+	var properties map[string]*Property
+
+	switch typ := path[2]; typ {
+	case ReferenceTypeDefinitions:
+		properties = remote.Definitions
+	case ReferenceTypeProperties:
+		properties = remote.Properties
+	default:
+		return nil, fmt.Errorf("unexpected Reference type: %s/%s/%s", ReferenceTypeRemote, path[1], typ)
+	}
+
+	property, ok := properties[path[3]]
+
+	if !ok || property == nil {
+		return nil, fmt.Errorf("%s/%s/%s/%s not found", ReferenceTypeRemote, path[1], path[2], path[3])
+	}
+
+	return r.resolvePropertyPath(ref, property, path[4:])
+}
+
+// rebaseDocument returns a document path relative to the base document.
+func rebaseDocument(document, base string) string {
+	switch {
+	case document == "":
+		return base
+	case isReferenceURL(document) || base == "":
+		return document
+	case isReferenceURL(base):
+		baseURL, err := url.Parse(base)
+
+		if err != nil {
+			return document
+		}
+
+		documentURL, err := url.Parse(document)
+
+		if err != nil {
+			return document
+		}
+
+		return baseURL.ResolveReference(documentURL).String()
+	default:
+		return path.Join(path.Dir(base), document)
+	}
+}
+
+// rebaseReference returns a Reference relative to the base document.
+func rebaseReference(ref Reference, base string) Reference {
+	_, fragment := ref.split()
+
+	return Reference(rebaseDocument(ref.Document(), base) + ReferenceAnchor + fragment)
+}
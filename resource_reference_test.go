@@ -0,0 +1,223 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestResourceExpand_ReferenceLoader(t *testing.T) {
+	common, err := os.ReadFile(filepath.Join("testdata", "initech.common.definitions.json"))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	testCases := []struct {
+		TestDescription string
+		Loader          cfschema.ReferenceLoader
+		ExpectError     bool
+	}{
+		{
+			TestDescription: "no loader",
+			ExpectError:     true,
+		},
+		{
+			TestDescription: "directory",
+			Loader:          cfschema.NewDirectoryReferenceLoader("testdata"),
+		},
+		{
+			TestDescription: "fs",
+			Loader: cfschema.NewFSReferenceLoader(fstest.MapFS{
+				"initech.common.definitions.json": &fstest.MapFile{Data: common},
+			}),
+		},
+		{
+			TestDescription: "map",
+			Loader: cfschema.MapReferenceLoader{
+				"initech.common.definitions.json": string(common),
+			},
+		},
+		{
+			TestDescription: "missing document",
+			Loader:          cfschema.MapReferenceLoader{},
+			ExpectError:     true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			resource := loadAndValidateResourceSchema(t, "provider.definition.schema.v1.json", "initech.tps.report.shared.v1.json")
+
+			resource.SetReferenceLoader(testCase.Loader)
+
+			err := resource.Expand()
+
+			if err != nil && !testCase.ExpectError {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err == nil && testCase.ExpectError {
+				t.Fatal("expected error, got none")
+			}
+
+			if err != nil {
+				return
+			}
+
+			for _, path := range [][]string{{"DueDate"}, {"Memo", "Heading"}, {"Approver", "ApprovalDate"}} {
+				property, _, err := resource.LookupPointer(cfschema.NewPropertyJsonPointer(path...))
+
+				if err != nil {
+					t.Fatalf("unexpected LookupPointer() error: %s", err)
+				}
+
+				if property.Ref != nil {
+					t.Errorf("expected no property (%s) ref, got: %s", strings.Join(path, "/"), property.Ref)
+				}
+
+				if actual, expected := property.Type.String(), cfschema.PropertyTypeString; actual != expected {
+					t.Errorf("expected property (%s) type (%s), got: %s", strings.Join(path, "/"), expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceJsonSchemaResource_ReferenceLoader(t *testing.T) {
+	resource := loadAndValidateResourceSchema(t, "provider.definition.schema.v1.json", "initech.tps.report.shared.v1.json")
+
+	if err := resource.Expand(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if actual, expected := resource.Properties["Memo"].Properties["Heading"].Type.String(), cfschema.PropertyTypeString; actual != expected {
+		t.Errorf("expected property type (%s), got: %s", expected, actual)
+	}
+}
+
+func TestResourceExpanded_ReferenceLoaderConcurrent(t *testing.T) {
+	resource := loadAndValidateResourceSchema(t, "provider.definition.schema.v1.json", "initech.tps.report.shared.v1.json")
+	clone := resource.Clone()
+
+	var wg sync.WaitGroup
+
+	// The original and its copy share loaded documents.
+	for _, r := range []*cfschema.Resource{resource, clone, resource, clone} {
+		wg.Add(1)
+
+		go func(r *cfschema.Resource) {
+			defer wg.Done()
+
+			if _, err := r.Expanded(); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}(r)
+	}
+
+	wg.Wait()
+}
+
+func TestResourceResolveReference_Document(t *testing.T) {
+	loader := cfschema.MapReferenceLoader{
+		"common/a.json":      `{"definitions": {"A": {"$ref": "b.json#/definitions/B"}, "Self": {"$ref": "#/definitions/Self"}, "Loop": {"type": "object", "properties": {"Next": {"$ref": "c.json#/definitions/Loop"}}}}}`,
+		"common/b.json":      `{"definitions": {"B": {"type": "object", "properties": {"C": {"$ref": "#/definitions/C"}}}, "C": {"type": "integer"}}}`,
+		"common/c.json":      `{"definitions": {"Loop": {"type": "object", "properties": {"Next": {"$ref": "a.json#/definitions/Loop"}}}}}`,
+		"common/string.json": `{"type": "string"}`,
+	}
+
+	testCases := []struct {
+		TestDescription string
+		Reference       cfschema.Reference
+		ExpectError     bool
+		ExpectedType    string
+	}{
+		{
+			TestDescription: "whole document",
+			Reference:       "file://./common/string.json",
+			ExpectedType:    cfschema.PropertyTypeString,
+		},
+		{
+			TestDescription: "relative chain",
+			Reference:       "common/a.json#/definitions/A",
+			ExpectedType:    cfschema.PropertyTypeObject,
+		},
+		{
+			TestDescription: "not found",
+			Reference:       "common/a.json#/definitions/Missing",
+			ExpectError:     true,
+		},
+		{
+			TestDescription: "cyclic chain",
+			Reference:       "common/a.json#/definitions/Self",
+			ExpectError:     true,
+		},
+		{
+			TestDescription: "recursive documents",
+			Reference:       "common/a.json#/definitions/Loop",
+			ExpectedType:    cfschema.PropertyTypeObject,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			resource := &cfschema.Resource{
+				Properties: map[string]*cfschema.Property{
+					"Test": {Ref: &testCase.Reference},
+				},
+				TypeName: new(string),
+			}
+
+			resource.SetReferenceLoader(loader)
+
+			err := resource.Expand()
+
+			if err != nil && !testCase.ExpectError {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err == nil && testCase.ExpectError {
+				t.Fatal("expected error, got none")
+			}
+
+			if err != nil {
+				return
+			}
+
+			property := resource.Properties["Test"]
+
+			if actual, expected := property.Type.String(), testCase.ExpectedType; actual != expected {
+				t.Errorf("expected type (%s), got: %s", expected, actual)
+			}
+
+			// Recursive references are kept in RecursiveRef, whose targets Walk would follow unexpanded.
+			err = cfschema.Walk(resource, func(v *cfschema.WalkProperty) error {
+				if v.Property.Ref != nil {
+					t.Errorf("expected fully expanded property, got %s: %s", v.Pointer, *v.Property.Ref)
+				}
+
+				if v.Property.RecursiveRef != nil {
+					return cfschema.SkipProperty
+				}
+
+				return nil
+			})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
@@ -125,6 +125,44 @@ func TestResourceResolveProperty(t *testing.T) {
 			ExpectRef:      false,
 			ExpectType:     true,
 		},
+		{
+			TestDescription: "nested definition ref",
+			Resource: &cfschema.Resource{
+				Definitions: map[string]*cfschema.Property{
+					"test": {
+						Ref: testReference("#/definitions/test2"),
+					},
+					"test2": {
+						Properties: map[string]*cfschema.Property{
+							"nested": {
+								Type: testType(cfschema.PropertyTypeArray),
+								Items: &cfschema.Property{
+									Type: testType(cfschema.PropertyTypeBoolean),
+								},
+							},
+						},
+					},
+				},
+			},
+			Property: &cfschema.Property{
+				Ref: testReference("#/definitions/test/properties/nested/items"),
+			},
+			ExpectResolved: true,
+			ExpectRef:      false,
+			ExpectType:     true,
+		},
+		{
+			TestDescription: "missing nested definition",
+			Resource: &cfschema.Resource{
+				Definitions: map[string]*cfschema.Property{
+					"test": {},
+				},
+			},
+			Property: &cfschema.Property{
+				Ref: testReference("#/definitions/test/properties/nested"),
+			},
+			ExpectError: true,
+		},
 		{
 			TestDescription: "property ref",
 			Resource: &cfschema.Resource{
@@ -197,3 +235,71 @@ func testType(t string) *cfschema.Type {
 
 	return &result
 }
+
+func TestResourceMetadata(t *testing.T) {
+	resource := loadAndValidateResourceSchema(t, "provider.definition.schema.v1.json", "initech.tps.report.typeconfiguration.v1.json")
+
+	testCases := []struct {
+		TestDescription string
+		Actual          *string
+		Expected        string
+	}{
+		{
+			TestDescription: "$schema",
+			Actual:          resource.Schema,
+			Expected:        "https://schema.cloudformation.us-east-1.amazonaws.com/provider.definition.schema.v1.json",
+		},
+		{
+			TestDescription: "title",
+			Actual:          resource.Title,
+			Expected:        "Initech TPS Report",
+		},
+		{
+			TestDescription: "documentationUrl",
+			Actual:          resource.DocumentationURL,
+			Expected:        "https://github.com/aws-cloudformation/aws-cloudformation-rpdk/blob/master/README.md",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			if testCase.Actual == nil {
+				t.Fatal("expected value, got none")
+			}
+
+			if actual, expected := *testCase.Actual, testCase.Expected; actual != expected {
+				t.Errorf("expected (%s), got: %s", expected, actual)
+			}
+		})
+	}
+}
+
+func TestResourceRemote(t *testing.T) {
+	resource := loadAndValidateResourceSchema(t, "provider.definition.schema.v1.json", "initech.tps.report.typeconfiguration.v1.json")
+
+	remote, ok := resource.Remote["schema0"]
+
+	if !ok || remote == nil {
+		t.Fatal("expected remote (schema0), got none")
+	}
+
+	if _, ok := remote.Definitions["Memo"]; !ok {
+		t.Fatal("expected remote definition (Memo), got none")
+	}
+
+	if err := resource.Expand(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	body := remote.Definitions["Memo"].Properties["Body"]
+
+	if body.Ref != nil {
+		t.Fatalf("expected no property ref, got: %s", body.Ref)
+	}
+
+	if actual, expected := body.Type.String(), cfschema.PropertyTypeString; actual != expected {
+		t.Errorf("expected property type (%s), got: %s", expected, actual)
+	}
+}
@@ -0,0 +1,917 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	SampleConfigurationMaximal = "maximal"
+	SampleConfigurationMinimal = "minimal"
+)
+
+// sampleConfigurationMaxDepth is the depth of nested objects beyond which only required properties are sampled.
+const sampleConfigurationMaxDepth = 8
+
+// sampleFormats are sample values for each string format.
+var sampleFormats = map[string]string{
+	PropertyFormatDate:                "2006-01-02",
+	PropertyFormatDateTime:            "2006-01-02T15:04:05Z",
+	PropertyFormatEmail:               "user@example.com",
+	PropertyFormatHostname:            "example.com",
+	PropertyFormatIdnEmail:            "user@example.com",
+	PropertyFormatIdnHostname:         "example.com",
+	PropertyFormatIpv4:                "192.0.2.1",
+	PropertyFormatIpv6:                "2001:db8::1",
+	PropertyFormatIri:                 "https://example.com",
+	PropertyFormatIriReference:        "https://example.com",
+	PropertyFormatJsonPointer:         "/example",
+	PropertyFormatRegex:               "^example$",
+	PropertyFormatRelativeJsonPointer: "0",
+	PropertyFormatTime:                "15:04:05Z",
+	PropertyFormatUri:                 "https://example.com",
+	PropertyFormatUriReference:        "https://example.com",
+	PropertyFormatUriTemplate:         "https://example.com/{id}",
+}
+
+// SampleConfiguration returns a sample configuration document for the Resource, of the kind:
+//
+//   - minimal: only required properties, including those required by oneOf, anyOf and dependencies
+//   - maximal: every writable property, choosing the first of any mutually exclusive properties
+//
+// Read-only properties are never included. Each value is, in order of preference, the const, default,
+// first enum value or first example of the property, or else a value satisfying its format, pattern,
+// length, item count and numeric bounds. Patterns must be Go compatible regular expressions.
+//
+// The document is not validated, see ResourceJsonSchema SampleConfiguration.
+func (r *Resource) SampleConfiguration(kind string) (string, error) {
+	if r == nil {
+		return "", fmt.Errorf("sampling configuration: Resource is required")
+	}
+
+	if kind != SampleConfigurationMaximal && kind != SampleConfigurationMinimal {
+		return "", fmt.Errorf("sampling configuration: unknown kind (%s)", kind)
+	}
+
+	expanded, err := r.Expanded()
+
+	if err != nil {
+		return "", fmt.Errorf("sampling configuration: %w", err)
+	}
+
+	g := newSampleGenerator(expanded)
+	g.maximal = kind == SampleConfigurationMaximal
+
+	document, err := g.object(g.root, nil, 0)
+
+	if err != nil {
+		return "", fmt.Errorf("sampling configuration: %w", err)
+	}
+
+	b, err := json.MarshalIndent(document, "", "  ")
+
+	if err != nil {
+		return "", fmt.Errorf("sampling configuration: %w", err)
+	}
+
+	return string(b), nil
+}
+
+// SampleConfiguration returns a sample configuration document of the kind, as described by
+// Resource SampleConfiguration, which has been validated against the resource schema.
+func (s *ResourceJsonSchema) SampleConfiguration(kind string) (string, error) {
+	if s == nil {
+		return "", fmt.Errorf("sampling configuration: ResourceJsonSchema is required")
+	}
+
+	resource, err := s.Resource()
+
+	if err != nil {
+		return "", err
+	}
+
+	document, err := resource.SampleConfiguration(kind)
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.ValidateConfigurationDocument(document); err != nil {
+		return "", fmt.Errorf("validating %s sample configuration: %w", kind, err)
+	}
+
+	return document, nil
+}
+
+// sampleGenerator generates sample values for the properties of an expanded Resource.
+// Without a random source, the first valid choice is always made.
+type sampleGenerator struct {
+	groups   map[PropertyJsonPointer]AttributeGroups
+	maximal  bool
+	rand     *rand.Rand
+	resource *Resource
+	root     *Property
+}
+
+// newSampleGenerator returns a sampleGenerator for the expanded Resource.
+func newSampleGenerator(resource *Resource) *sampleGenerator {
+	return &sampleGenerator{
+		groups:   resource.AttributeGroups().ByObject(),
+		resource: resource,
+		root: &Property{
+			AllOf:           resource.AllOf,
+			AnyOf:           resource.AnyOf,
+			OneOf:           resource.OneOf,
+			Properties:      resource.Properties,
+			PropertiesOrder: resource.PropertiesOrder,
+			Required:        resource.Required,
+		},
+	}
+}
+
+// intn returns a random number in [0, n), or 0 without a random source.
+func (g *sampleGenerator) intn(n int) int {
+	if g.rand == nil || n <= 1 {
+		return 0
+	}
+
+	return g.rand.Intn(n)
+}
+
+// sometimes returns true one in n times, or never without a random source.
+func (g *sampleGenerator) sometimes(n int) bool {
+	return g.rand != nil && g.rand.Intn(n) == 0
+}
+
+// object returns a sample value for an object property at the path.
+func (g *sampleGenerator) object(property *Property, path []string, depth int) (map[string]interface{}, error) {
+	properties := subschemaProperties(property)
+	required := make(map[string]bool)
+	excluded := make(map[string]bool)
+
+	for _, name := range sampleRequired(property) {
+		required[name] = true
+	}
+
+	groups := g.groups[NewPropertyJsonPointer(path...)]
+
+	// A property of each group is chosen, then others which cannot be configured with it are excluded.
+	chosen := make(map[*AttributeGroup]string)
+
+	for _, group := range groups {
+		name := group.Pointers[g.intn(len(group.Pointers))].Path()
+		chosen[group] = name[len(name)-1]
+
+		switch group.Type {
+		case AttributeGroupTypeAtLeastOneOf, AttributeGroupTypeExactlyOneOf:
+			required[chosen[group]] = true
+		}
+	}
+
+	for _, group := range groups {
+		if group.Type != AttributeGroupTypeConflictsWith && group.Type != AttributeGroupTypeExactlyOneOf {
+			continue
+		}
+
+		for _, pointer := range group.Pointers {
+			path := pointer.Path()
+
+			if name := path[len(path)-1]; name != chosen[group] && !required[name] {
+				excluded[name] = true
+			}
+		}
+	}
+
+	included := make(map[string]bool)
+
+	for _, name := range orderedSubschemaPropertyNames(property) {
+		if excluded[name] || walkPointersContain(g.resource.ReadOnlyProperties, appendPath(path, name)) {
+			continue
+		}
+
+		if required[name] {
+			included[name] = true
+			continue
+		}
+
+		if (g.maximal || g.sometimes(2)) && depth < sampleConfigurationMaxDepth && properties[name] != nil && properties[name].RecursiveRef == nil {
+			included[name] = true
+		}
+	}
+
+	// Properties configured with another property.
+	for changed := true; changed; {
+		changed = false
+
+		for _, group := range groups {
+			if group.Type != AttributeGroupTypeRequiredWith {
+				continue
+			}
+
+			first := group.Pointers[0].Path()
+
+			if !included[first[len(first)-1]] {
+				continue
+			}
+
+			for _, pointer := range group.Pointers[1:] {
+				name := pointer.Path()[len(pointer.Path())-1]
+
+				if _, ok := properties[name]; ok && !included[name] && !excluded[name] {
+					included[name] = true
+					changed = true
+				}
+			}
+		}
+	}
+
+	value := make(map[string]interface{})
+
+	for _, name := range orderedSubschemaPropertyNames(property) {
+		if !included[name] {
+			continue
+		}
+
+		v, err := g.value(properties[name], appendPath(path, name), depth+1, 0)
+
+		if err != nil {
+			return nil, err
+		}
+
+		value[name] = v
+	}
+
+	if patterns := property.OrderedPatternPropertyNames(); len(properties) == 0 && len(patterns) > 0 {
+		count := 0
+
+		switch {
+		case depth >= sampleConfigurationMaxDepth:
+		case g.rand != nil:
+			count = g.intn(2*len(patterns) + 1)
+		case g.maximal:
+			count = len(patterns)
+		}
+
+		if property.MinProperties != nil && count < *property.MinProperties {
+			count = *property.MinProperties
+		}
+
+		if property.MaxProperties != nil && count > *property.MaxProperties {
+			count = *property.MaxProperties
+		}
+
+		minLength := 1
+
+		for i := 0; len(value) < count && i < count*4; i++ {
+			pattern := patterns[(i+g.intn(len(patterns)))%len(patterns)]
+			key, err := g.string(&Property{MinLength: &minLength, Pattern: &pattern}, i)
+
+			if err != nil {
+				return nil, fmt.Errorf("%s: pattern property %s: %w", NewPropertyJsonPointer(path...), pattern, err)
+			}
+
+			if _, ok := value[key]; ok {
+				continue
+			}
+
+			v, err := g.value(property.PatternProperties[pattern], appendPath(path, key), depth+1, i)
+
+			if err != nil {
+				return nil, err
+			}
+
+			value[key] = v
+		}
+	}
+
+	return value, nil
+}
+
+// sampleRequired returns the names of the properties required by the property, by its allOf subschemas
+// and by every one of its anyOf or oneOf subschemas, including any unwrapped oneOf subschemas.
+func sampleRequired(property *Property) []string {
+	required := sampleSubschemaRequired(&PropertySubschema{
+		AllOf:    property.AllOf,
+		AnyOf:    property.AnyOf,
+		OneOf:    property.OneOf,
+		Required: property.Required,
+	})
+
+	return unionStrings(required, sampleAlternativesRequired(property.UnwrappedOneOf))
+}
+
+// sampleSubschemaRequired returns the names of the properties required by the subschema, recursively.
+func sampleSubschemaRequired(subschema *PropertySubschema) []string {
+	required := cloneSlice(subschema.Required)
+
+	for _, allOf := range subschema.AllOf {
+		if allOf != nil {
+			required = unionStrings(required, sampleSubschemaRequired(allOf))
+		}
+	}
+
+	required = unionStrings(required, sampleAlternativesRequired(subschema.AnyOf))
+
+	return unionStrings(required, sampleAlternativesRequired(subschema.OneOf))
+}
+
+// sampleAlternativesRequired returns the names of the properties required by every one of the subschemas.
+func sampleAlternativesRequired(subschemas []*PropertySubschema) []string {
+	var required []string
+
+	for i, subschema := range subschemas {
+		if subschema == nil {
+			return nil
+		}
+
+		if i == 0 {
+			required = sampleSubschemaRequired(subschema)
+		} else {
+			required = intersectStrings(required, sampleSubschemaRequired(subschema))
+		}
+	}
+
+	return required
+}
+
+// value returns a sample value for a property at the path.
+// Items of arrays are distinguished by index, so that they can be unique.
+func (g *sampleGenerator) value(property *Property, path []string, depth, index int) (interface{}, error) {
+	if property == nil || property.RecursiveRef != nil {
+		return map[string]interface{}{}, nil
+	}
+
+	switch {
+	case property.Const != nil:
+		return property.Const, nil
+	case property.Default != nil && ((g.rand == nil && index == 0) || g.sometimes(4)):
+		return property.Default, nil
+	case len(property.Enum) > 0:
+		return property.Enum[(index+g.intn(len(property.Enum)))%len(property.Enum)], nil
+	case len(property.Examples) > 0 && ((g.rand == nil && index < len(property.Examples)) || g.sometimes(2)):
+		return property.Examples[(index+g.intn(len(property.Examples)))%len(property.Examples)], nil
+	}
+
+	typ := property.Type.Primary()
+
+	if types := property.Type.Types(); g.rand != nil && len(types) > 1 {
+		typ = types[g.intn(len(types))]
+	}
+
+	if typ == "" {
+		switch {
+		case len(subschemaProperties(property)) > 0 || len(property.PatternProperties) > 0:
+			typ = PropertyTypeObject
+		case property.Items != nil:
+			typ = PropertyTypeArray
+		default:
+			typ = PropertyTypeString
+		}
+	}
+
+	switch typ {
+	case PropertyTypeArray:
+		return g.array(property, path, depth)
+	case PropertyTypeBoolean:
+		if g.rand != nil {
+			return g.sometimes(2), nil
+		}
+
+		return index%2 == 0, nil
+	case PropertyTypeInteger, PropertyTypeNumber:
+		v, err := g.number(property, typ == PropertyTypeInteger, index)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", NewPropertyJsonPointer(path...), err)
+		}
+
+		return v, nil
+	case PropertyTypeNull:
+		return nil, nil
+	case PropertyTypeObject:
+		return g.object(property, path, depth)
+	}
+
+	v, err := g.string(property, index)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", NewPropertyJsonPointer(path...), err)
+	}
+
+	return v, nil
+}
+
+// array returns a sample value for an array property at the path.
+func (g *sampleGenerator) array(property *Property, path []string, depth int) ([]interface{}, error) {
+	count := 0
+
+	if property.MinItems != nil {
+		count = *property.MinItems
+	}
+
+	if g.maximal && count == 0 && depth < sampleConfigurationMaxDepth {
+		count = 1
+	}
+
+	if g.rand != nil && depth < sampleConfigurationMaxDepth {
+		count += g.intn(4)
+	}
+
+	if property.MaxItems != nil && count > *property.MaxItems {
+		count = *property.MaxItems
+	}
+
+	unique := property.UniqueItems != nil && *property.UniqueItems
+	values := make([]interface{}, 0, count)
+
+	for i := 0; len(values) < count && i < count*4+1; i++ {
+		v, err := g.value(property.Items, appendPath(path, PropertyJsonPointerWildcard), depth, i)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if unique && sampleContains(values, v) {
+			continue
+		}
+
+		values = append(values, v)
+	}
+
+	return values, nil
+}
+
+// sampleContains returns true if the JSON representation of a value is in the list.
+func sampleContains(values []interface{}, v interface{}) bool {
+	b, _ := json.Marshal(v)
+
+	for _, value := range values {
+		if c, _ := json.Marshal(value); string(c) == string(b) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// number returns a number within the bounds of a property, varying by index or chosen at random.
+func (g *sampleGenerator) number(property *Property, integer bool, index int) (interface{}, error) {
+	if g.rand != nil {
+		if v, ok := sampleRandomNumber(property, integer, g.rand); ok {
+			return v, nil
+		}
+	}
+
+	v, ok := sampleNumber(property, integer, index)
+
+	if !ok {
+		return nil, fmt.Errorf("no sample number satisfies minimum, maximum and multipleOf")
+	}
+
+	return v, nil
+}
+
+// sampleBound returns the value of an optional numeric keyword.
+func sampleBound(n *json.Number) (float64, bool) {
+	if n == nil {
+		return 0, false
+	}
+
+	f, err := n.Float64()
+
+	return f, err == nil
+}
+
+// sampleNumber returns a number within the bounds of a property, varying by index. Values closest to 1
+// are preferred and successive indexes cycle through the multiples within the bounds.
+// Returns false if the bounds cannot be satisfied.
+func sampleNumber(property *Property, integer bool, index int) (json.Number, bool) {
+	m, first, last, ok := sampleMultiples(property, integer)
+
+	if !ok {
+		return "", false
+	}
+
+	// Start from the least multiple of at least 1.
+	k, _ := sampleFactor(big.NewRat(1, 1), m, true)
+
+	if k.Cmp(first) < 0 {
+		k.Set(first)
+	}
+
+	if k.Cmp(last) > 0 {
+		k.Set(last)
+	}
+
+	count := new(big.Int).Sub(last, first)
+	count.Add(count, big.NewInt(1))
+
+	k.Sub(k, first)
+	k.Add(k, big.NewInt(int64(index)))
+	k.Mod(k, count)
+	k.Add(k, first)
+
+	return sampleRat(new(big.Rat).Mul(m, new(big.Rat).SetInt(k))), true
+}
+
+// sampleMultiples returns the multiple that numbers of a property are sampled from, with the range [first, last]
+// of its factors within the bounds, which default to a range of 1000. Without multipleOf, whole numbers are
+// preferred to thousandths. Returns false if the bounds cannot be satisfied.
+func sampleMultiples(property *Property, integer bool) (*big.Rat, *big.Int, *big.Int, bool) {
+	lo, loExclusive := sampleRatBound(property.Minimum), false
+	hi, hiExclusive := sampleRatBound(property.Maximum), false
+
+	if v := sampleRatBound(property.ExclusiveMinimum); v != nil && (lo == nil || v.Cmp(lo) >= 0) {
+		lo, loExclusive = v, true
+	}
+
+	if v := sampleRatBound(property.ExclusiveMaximum); v != nil && (hi == nil || v.Cmp(hi) <= 0) {
+		hi, hiExclusive = v, true
+	}
+
+	switch {
+	case lo == nil && hi == nil:
+		lo, hi = big.NewRat(-1000, 1), big.NewRat(1000, 1)
+	case lo == nil:
+		lo = new(big.Rat).Sub(hi, big.NewRat(1000, 1))
+	case hi == nil:
+		hi = new(big.Rat).Add(lo, big.NewRat(1000, 1))
+	}
+
+	multiples := []*big.Rat{big.NewRat(1, 1)}
+
+	switch {
+	case property.MultipleOf != nil:
+		m := sampleRatBound(property.MultipleOf)
+
+		if m == nil || m.Sign() <= 0 {
+			return nil, nil, nil, false
+		}
+
+		// The least common multiple of p/q and 1 is p.
+		if integer {
+			m = new(big.Rat).SetInt(m.Num())
+		}
+
+		multiples = []*big.Rat{m}
+	case !integer:
+		multiples = append(multiples, big.NewRat(1, 1000))
+	}
+
+	for _, m := range multiples {
+		first, exact := sampleFactor(lo, m, true)
+
+		if exact && loExclusive {
+			first.Add(first, big.NewInt(1))
+		}
+
+		last, exact := sampleFactor(hi, m, false)
+
+		if exact && hiExclusive {
+			last.Sub(last, big.NewInt(1))
+		}
+
+		if first.Cmp(last) <= 0 {
+			return m, first, last, true
+		}
+	}
+
+	return nil, nil, nil, false
+}
+
+// sampleFactor returns the ceiling, or floor, of v/m and whether the division is exact.
+func sampleFactor(v, m *big.Rat, ceil bool) (*big.Int, bool) {
+	q := new(big.Rat).Quo(v, m)
+	k, r := new(big.Int).DivMod(q.Num(), q.Denom(), new(big.Int))
+
+	if r.Sign() == 0 {
+		return k, true
+	}
+
+	if ceil {
+		k.Add(k, big.NewInt(1))
+	}
+
+	return k, false
+}
+
+// sampleRatBound returns the exact value of an optional numeric keyword, or nil.
+func sampleRatBound(n *json.Number) *big.Rat {
+	if n == nil {
+		return nil
+	}
+
+	v, ok := new(big.Rat).SetString(n.String())
+
+	if !ok {
+		return nil
+	}
+
+	return v
+}
+
+// sampleRat returns the exact JSON representation of a rational sample number.
+func sampleRat(v *big.Rat) json.Number {
+	if v.IsInt() {
+		return json.Number(v.Num().String())
+	}
+
+	return json.Number(strings.TrimRight(v.FloatString(10), "0"))
+}
+
+// sampleRandomNumber returns a random number within the bounds of a property, defaulting to a range of 1000.
+// Multiples are returned exactly as a json.Number. Returns false if the bounds cannot be satisfied.
+func sampleRandomNumber(property *Property, integer bool, rnd *rand.Rand) (interface{}, bool) {
+	if property.MultipleOf != nil {
+		m, first, last, ok := sampleMultiples(property, integer)
+
+		if !ok {
+			return nil, false
+		}
+
+		count := new(big.Int).Sub(last, first)
+		count.Add(count, big.NewInt(1))
+
+		k := new(big.Int).Rand(rnd, count)
+		k.Add(k, first)
+
+		return sampleRat(new(big.Rat).Mul(m, new(big.Rat).SetInt(k))), true
+	}
+
+	step := 0.001
+
+	if integer {
+		step = 1
+	}
+
+	lo, hasLo := sampleBound(property.Minimum)
+	hi, hasHi := sampleBound(property.Maximum)
+
+	if v, ok := sampleBound(property.ExclusiveMinimum); ok && (!hasLo || v >= lo) {
+		lo, hasLo = v+step, true
+	}
+
+	if v, ok := sampleBound(property.ExclusiveMaximum); ok && (!hasHi || v <= hi) {
+		hi, hasHi = v-step, true
+	}
+
+	switch {
+	case !hasLo && !hasHi:
+		lo, hi = -1000, 1000
+	case !hasLo:
+		lo = hi - 1000
+	case !hasHi:
+		hi = lo + 1000
+	}
+
+	if integer {
+		lo, hi = math.Ceil(lo), math.Floor(hi)
+	}
+
+	if lo > hi {
+		return nil, false
+	}
+
+	if integer {
+		return int64(lo) + rnd.Int63n(int64(hi-lo)+1), true
+	}
+
+	v := math.Round((lo+rnd.Float64()*(hi-lo))/step) * step
+
+	return math.Max(lo, math.Min(hi, v)), true
+}
+
+// string returns a string satisfying the format, pattern and length of a property, varying by index or chosen at random.
+func (g *sampleGenerator) string(property *Property, index int) (string, error) {
+	minLength, maxLength := 0, -1
+
+	if property.MinLength != nil {
+		minLength = *property.MinLength
+	}
+
+	if property.MaxLength != nil {
+		maxLength = *property.MaxLength
+	}
+
+	if property.Pattern != nil && *property.Pattern != "" {
+		return samplePatternString(*property.Pattern, index, minLength, maxLength, g.rand)
+	}
+
+	v := "example"
+
+	if property.Format != nil {
+		if s, ok := sampleFormats[*property.Format]; ok {
+			v = s
+		}
+	}
+
+	if g.rand != nil && v == "example" {
+		return sampleRandomString(g.rand, minLength, maxLength), nil
+	}
+
+	if index > 0 && v == "example" {
+		v = fmt.Sprintf("%s%d", v, index)
+	}
+
+	for utf8.RuneCountInString(v) < minLength {
+		v += "x"
+	}
+
+	if maxLength >= 0 && utf8.RuneCountInString(v) > maxLength {
+		v = string([]rune(v)[:maxLength])
+	}
+
+	return v, nil
+}
+
+// sampleAlphanumerics are the characters of random strings.
+const sampleAlphanumerics = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// sampleRandomString returns a random alphanumeric string within the length bounds,
+// where a negative maxLength is unbounded.
+func sampleRandomString(rnd *rand.Rand, minLength, maxLength int) string {
+	lo := max(minLength, 1)
+	hi := lo + 15
+
+	if maxLength >= 0 {
+		lo, hi = min(lo, maxLength), min(hi, maxLength)
+	}
+
+	b := make([]byte, lo+rnd.Intn(hi-lo+1))
+
+	for i := range b {
+		b[i] = sampleAlphanumerics[rnd.Intn(len(sampleAlphanumerics))]
+	}
+
+	return string(b)
+}
+
+// samplePatternString returns a string matching the Go regular expression and length bounds, where a
+// negative maxLength is unbounded. Unbounded repetitions are repeated increasingly often, starting from
+// offset, until the length bounds are met. With a random source, random choices are tried first.
+func samplePatternString(pattern string, offset, minLength, maxLength int, rnd *rand.Rand) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+
+	if err != nil {
+		return "", fmt.Errorf("parsing pattern (%s): %w", pattern, err)
+	}
+
+	matcher, err := regexp.Compile(pattern)
+
+	if err != nil {
+		return "", fmt.Errorf("parsing pattern (%s): %w", pattern, err)
+	}
+
+	re = re.Simplify()
+
+	matches := func(v string) bool {
+		length := utf8.RuneCountInString(v)
+
+		return length >= minLength && (maxLength < 0 || length <= maxLength) && matcher.MatchString(v)
+	}
+
+	if rnd != nil {
+		for spread := 3; spread < 3+64; spread++ {
+			var sb strings.Builder
+
+			if !samplePattern(&sb, re, spread, rnd) {
+				break
+			}
+
+			if v := sb.String(); matches(v) {
+				return v, nil
+			}
+		}
+	}
+
+	for repeat := offset; repeat <= offset+64; repeat++ {
+		var sb strings.Builder
+
+		if !samplePattern(&sb, re, repeat, nil) {
+			break
+		}
+
+		if v := sb.String(); matches(v) {
+			return v, nil
+		}
+	}
+
+	return "", fmt.Errorf("no sample string matches pattern (%s) with length between %d and %d", pattern, minLength, maxLength)
+}
+
+// samplePattern writes a string matching the parsed regular expression. Returns false if nothing can match.
+//
+// Without a random source, the first alternative is chosen and repetitions are repeated the given number of
+// times beyond their minimum. With a random source, alternatives are chosen at random and repetitions are
+// repeated up to the given number of times beyond their minimum.
+func samplePattern(sb *strings.Builder, re *syntax.Regexp, repeat int, rnd *rand.Rand) bool {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return false
+	case syntax.OpLiteral:
+		sb.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		if len(re.Rune) == 0 {
+			return false
+		}
+
+		sb.WriteRune(sampleCharClass(re.Rune, rnd))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		if rnd != nil {
+			sb.WriteByte(sampleAlphanumerics[rnd.Intn(len(sampleAlphanumerics))])
+		} else {
+			sb.WriteRune('a')
+		}
+	case syntax.OpCapture:
+		return samplePattern(sb, re.Sub[0], repeat, rnd)
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		lo, hi := 0, -1
+
+		switch re.Op {
+		case syntax.OpPlus:
+			lo = 1
+		case syntax.OpQuest:
+			hi = 1
+		case syntax.OpRepeat:
+			lo, hi = re.Min, re.Max
+		}
+
+		n := lo + repeat
+
+		if rnd != nil {
+			n = lo + rnd.Intn(repeat+1)
+		}
+
+		if hi >= 0 && n > hi {
+			n = hi
+		}
+
+		for i := 0; i < n; i++ {
+			if !samplePattern(sb, re.Sub[0], repeat, rnd) {
+				return false
+			}
+		}
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if !samplePattern(sb, sub, repeat, rnd) {
+				return false
+			}
+		}
+	case syntax.OpAlternate:
+		if rnd != nil {
+			return samplePattern(sb, re.Sub[rnd.Intn(len(re.Sub))], repeat, rnd)
+		}
+
+		return samplePattern(sb, re.Sub[0], repeat, rnd)
+	}
+
+	return true
+}
+
+// sampleCharClass returns a rune in the character class, preferring letters and digits, or a random
+// printable ASCII rune in the class with a random source.
+func sampleCharClass(ranges []rune, rnd *rand.Rand) rune {
+	if rnd != nil {
+		var printable []rune
+
+		for i := 0; i+1 < len(ranges); i += 2 {
+			for c := max(ranges[i], '!'); c <= min(ranges[i+1], '~'); c++ {
+				printable = append(printable, c)
+			}
+		}
+
+		if len(printable) > 0 {
+			return printable[rnd.Intn(len(printable))]
+		}
+	}
+
+	for _, preferred := range []rune{'a', 'A', '0'} {
+		for i := 0; i+1 < len(ranges); i += 2 {
+			if ranges[i] <= preferred && preferred <= ranges[i+1] {
+				return preferred
+			}
+		}
+	}
+
+	for i := 0; i+1 < len(ranges); i += 2 {
+		if lo := ranges[i]; lo > ' ' {
+			return lo
+		}
+
+		if hi := ranges[i+1]; hi > ' ' {
+			return ' ' + 1
+		}
+	}
+
+	return ranges[0]
+}
@@ -0,0 +1,308 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestResourceSampleConfiguration(t *testing.T) {
+	testCases := []struct {
+		TestDescription string
+		Schema          string
+		Kind            string
+		Expected        string
+		ExpectError     bool
+	}{
+		{
+			TestDescription: "unknown kind",
+			Schema:          `{"typeName": "Initech::TPS::Report", "properties": {"Title": {"type": "string"}}}`,
+			Kind:            "average",
+			ExpectError:     true,
+		},
+		{
+			TestDescription: "minimal required only",
+			Schema: `{
+				"typeName": "Initech::TPS::Report",
+				"properties": {
+					"Title": {"type": "string"},
+					"Code": {"type": "string"}
+				},
+				"required": ["Code"]
+			}`,
+			Kind:     cfschema.SampleConfigurationMinimal,
+			Expected: `{"Code": "example"}`,
+		},
+		{
+			TestDescription: "maximal skips read-only",
+			Schema: `{
+				"typeName": "Initech::TPS::Report",
+				"properties": {
+					"Id": {"type": "string"},
+					"Title": {"type": "string"},
+					"Code": {"type": "string"}
+				},
+				"required": ["Code"],
+				"readOnlyProperties": ["/properties/Id"]
+			}`,
+			Kind:     cfschema.SampleConfigurationMaximal,
+			Expected: `{"Code": "example", "Title": "example"}`,
+		},
+		{
+			TestDescription: "values",
+			Schema: `{
+				"typeName": "Initech::TPS::Report",
+				"properties": {
+					"Const": {"type": "string", "const": "fixed"},
+					"Default": {"type": "integer", "default": 7},
+					"Enum": {"type": "string", "enum": ["LOW", "HIGH"]},
+					"Example": {"type": "string", "examples": ["sample"]},
+					"Format": {"type": "string", "format": "ipv4"},
+					"Flag": {"type": "boolean"},
+					"Long": {"type": "string", "minLength": 10},
+					"Short": {"type": "string", "maxLength": 3},
+					"Pattern": {"type": "string", "pattern": "^[a-z]{3}-[0-9]+$"},
+					"Bounded": {"type": "integer", "minimum": 100, "maximum": 200},
+					"Exclusive": {"type": "number", "exclusiveMaximum": 0},
+					"Multiple": {"type": "integer", "minimum": 11, "multipleOf": 5},
+					"Nullable": {"type": ["string", "null"]}
+				},
+				"required": ["Const", "Default", "Enum", "Example", "Format", "Flag", "Long", "Short", "Pattern", "Bounded", "Exclusive", "Multiple", "Nullable"]
+			}`,
+			Kind: cfschema.SampleConfigurationMinimal,
+			Expected: `{
+				"Bounded": 100,
+				"Const": "fixed",
+				"Default": 7,
+				"Enum": "LOW",
+				"Example": "sample",
+				"Exclusive": -1,
+				"Flag": true,
+				"Format": "192.0.2.1",
+				"Long": "examplexxx",
+				"Multiple": 15,
+				"Nullable": "example",
+				"Pattern": "aaa-0",
+				"Short": "exa"
+			}`,
+		},
+		{
+			TestDescription: "number bounds and multiples",
+			Schema: `{
+				"typeName": "Initech::TPS::Report",
+				"properties": {
+					"Cycled": {"type": "array", "minItems": 5, "items": {"type": "integer", "minimum": 1, "maximum": 3}},
+					"Exclusive": {"type": "number", "exclusiveMinimum": 0, "maximum": 1},
+					"Fraction": {"type": "number", "minimum": 0.25, "maximum": 0.3, "multipleOf": 0.1},
+					"Multiple": {"type": "integer", "minimum": 1, "maximum": 10, "multipleOf": 3},
+					"Multiples": {"type": "array", "minItems": 4, "items": {"type": "number", "minimum": 1, "maximum": 10, "multipleOf": 3}},
+					"Narrow": {"type": "number", "exclusiveMinimum": 0.5, "exclusiveMaximum": 0.6}
+				},
+				"required": ["Cycled", "Exclusive", "Fraction", "Multiple", "Multiples", "Narrow"]
+			}`,
+			Kind:     cfschema.SampleConfigurationMinimal,
+			Expected: `{"Cycled": [1, 2, 3, 1, 2], "Exclusive": 1, "Fraction": 0.3, "Multiple": 3, "Multiples": [3, 6, 9, 3], "Narrow": 0.599}`,
+		},
+		{
+			TestDescription: "unsatisfiable multiple",
+			Schema: `{
+				"typeName": "Initech::TPS::Report",
+				"properties": {"Count": {"type": "integer", "minimum": 1, "maximum": 2, "multipleOf": 3}},
+				"required": ["Count"]
+			}`,
+			Kind:        cfschema.SampleConfigurationMinimal,
+			ExpectError: true,
+		},
+		{
+			TestDescription: "arrays",
+			Schema: `{
+				"typeName": "Initech::TPS::Report",
+				"properties": {
+					"Empty": {"type": "array", "items": {"type": "string"}},
+					"Unique": {"type": "array", "minItems": 3, "uniqueItems": true, "items": {"type": "integer"}},
+					"Enum": {"type": "array", "minItems": 2, "uniqueItems": true, "items": {"type": "string", "enum": ["A", "B", "C"]}}
+				},
+				"required": ["Empty", "Unique", "Enum"]
+			}`,
+			Kind:     cfschema.SampleConfigurationMinimal,
+			Expected: `{"Empty": [], "Enum": ["A", "B"], "Unique": [1, 2, 3]}`,
+		},
+		{
+			TestDescription: "maximal arrays and nested objects",
+			Schema: `{
+				"typeName": "Initech::TPS::Report",
+				"definitions": {
+					"Tag": {
+						"type": "object",
+						"properties": {"Key": {"type": "string"}, "Value": {"type": "string"}},
+						"required": ["Key"],
+						"additionalProperties": false
+					}
+				},
+				"properties": {
+					"Tags": {"type": "array", "maxItems": 5, "items": {"$ref": "#/definitions/Tag"}},
+					"Labels": {"type": "object", "patternProperties": {"^[a-z]+$": {"type": "string"}}}
+				},
+				"additionalProperties": false
+			}`,
+			Kind:     cfschema.SampleConfigurationMaximal,
+			Expected: `{"Labels": {"a": "example"}, "Tags": [{"Key": "example", "Value": "example"}]}`,
+		},
+		{
+			TestDescription: "attribute groups",
+			Schema: `{
+				"typeName": "Initech::TPS::Report",
+				"properties": {
+					"Bucket": {"type": "string"},
+					"Key": {"type": "string"},
+					"Inline": {"type": "string"},
+					"Region": {"type": "string"}
+				},
+				"oneOf": [
+					{"required": ["Bucket", "Key"]},
+					{"required": ["Inline"]}
+				],
+				"dependencies": {"Region": ["Bucket"]}
+			}`,
+			Kind:     cfschema.SampleConfigurationMaximal,
+			Expected: `{"Bucket": "example", "Key": "example", "Region": "example"}`,
+		},
+		{
+			TestDescription: "attribute groups minimal",
+			Schema: `{
+				"typeName": "Initech::TPS::Report",
+				"properties": {
+					"Bucket": {"type": "string"},
+					"Key": {"type": "string"},
+					"Inline": {"type": "string"}
+				},
+				"oneOf": [
+					{"required": ["Bucket", "Key"]},
+					{"required": ["Inline"]}
+				]
+			}`,
+			Kind:     cfschema.SampleConfigurationMinimal,
+			Expected: `{"Bucket": "example", "Key": "example"}`,
+		},
+		{
+			TestDescription: "required by every oneOf branch",
+			Schema: `{
+				"typeName": "Initech::TPS::Report",
+				"properties": {
+					"Name": {"type": "string"},
+					"Bucket": {"type": "string"},
+					"Inline": {"type": "string"},
+					"Title": {"type": "string"}
+				},
+				"oneOf": [
+					{"required": ["Name", "Bucket"]},
+					{"required": ["Name", "Inline"]}
+				],
+				"anyOf": [
+					{"allOf": [{"required": ["Title"]}]},
+					{"required": ["Title", "Bucket"]}
+				]
+			}`,
+			Kind:     cfschema.SampleConfigurationMinimal,
+			Expected: `{"Bucket": "example", "Name": "example", "Title": "example"}`,
+		},
+		{
+			TestDescription: "minimal pattern properties minProperties",
+			Schema: `{
+				"typeName": "Initech::TPS::Report",
+				"properties": {
+					"Labels": {"type": "object", "minProperties": 2, "patternProperties": {"^[a-z]+$": {"type": "string"}}}
+				},
+				"required": ["Labels"]
+			}`,
+			Kind:     cfschema.SampleConfigurationMinimal,
+			Expected: `{"Labels": {"a": "example", "aa": "example1"}}`,
+		},
+		{
+			TestDescription: "maximal pattern properties maxProperties",
+			Schema: `{
+				"typeName": "Initech::TPS::Report",
+				"properties": {
+					"Labels": {"type": "object", "maxProperties": 1, "patternProperties": {"^[a-z]+$": {"type": "string"}, "^[0-9]+$": {"type": "string"}}}
+				}
+			}`,
+			Kind:     cfschema.SampleConfigurationMaximal,
+			Expected: `{"Labels": {"a": "example"}}`,
+		},
+		{
+			TestDescription: "unsatisfiable pattern",
+			Schema: `{
+				"typeName": "Initech::TPS::Report",
+				"properties": {"Code": {"type": "string", "pattern": "^[0-9]{2}$", "minLength": 3}},
+				"required": ["Code"]
+			}`,
+			Kind:        cfschema.SampleConfigurationMinimal,
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			resourceSchema, err := cfschema.NewResourceJsonSchemaDocument(testCase.Schema)
+
+			if err != nil {
+				t.Fatalf("unexpected NewResourceJsonSchemaDocument() error: %s", err)
+			}
+
+			got, err := resourceSchema.SampleConfiguration(testCase.Kind)
+
+			if err != nil && !testCase.ExpectError {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err == nil && testCase.ExpectError {
+				t.Fatalf("expected error, got none")
+			}
+
+			if err != nil {
+				return
+			}
+
+			if compactJSON(t, got) != compactJSON(t, testCase.Expected) {
+				t.Errorf("expected %s, got %s", compactJSON(t, testCase.Expected), compactJSON(t, got))
+			}
+		})
+	}
+}
+
+func TestResourceJsonSchemaSampleConfiguration(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "AWS_*.json"))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	paths = append(paths, filepath.Join("testdata", "initech.tps.report.v1.json"))
+
+	for _, path := range paths {
+		path := path
+
+		for _, kind := range []string{cfschema.SampleConfigurationMinimal, cfschema.SampleConfigurationMaximal} {
+			kind := kind
+
+			t.Run(strings.Join([]string{filepath.Base(path), kind}, " "), func(t *testing.T) {
+				resourceSchema, err := cfschema.NewResourceJsonSchemaPath(path)
+
+				if err != nil {
+					t.Fatalf("unexpected NewResourceJsonSchemaPath() error: %s", err)
+				}
+
+				if _, err := resourceSchema.SampleConfiguration(kind); err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			})
+		}
+	}
+}
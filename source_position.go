@@ -0,0 +1,257 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// SourcePosition is the location of a value in a JSON document.
+//
+// Line and Column start at 1, with Column counted in bytes. Offset is the byte offset from the start of the document.
+// Filename is empty for documents not loaded from a file path. For object members, the position is that of the member key.
+type SourcePosition struct {
+	Column   int
+	Filename string
+	Line     int
+	Offset   int
+}
+
+// String returns a string representation of SourcePosition, e.g. schema.json:12:5.
+func (p *SourcePosition) String() string {
+	if p == nil {
+		return ""
+	}
+
+	if p.Filename == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+
+	return fmt.Sprintf("%s:%d:%d", p.Filename, p.Line, p.Column)
+}
+
+// SourcePosition returns the location in the schema document of the value at the RFC 6901 JSON Pointer, e.g.
+// /definitions/Tag/properties/Key or /readOnlyProperties/0 for the first entry of readOnlyProperties.
+// Returns nil if the location is not known.
+//
+// Locations are only known for Resources returned by ResourceJsonSchema Resource, which also sets the
+// SourcePosition of each Property and Handler.
+func (r *Resource) SourcePosition(pointer string) *SourcePosition {
+	if r == nil {
+		return nil
+	}
+
+	return r.sourcePositions[pointer]
+}
+
+// setSourcePositions records the document locations on the Resource and on each Property and Handler.
+func (r *Resource) setSourcePositions(positions map[string]*SourcePosition) {
+	r.sourcePositions = positions
+
+	if len(positions) == 0 {
+		return
+	}
+
+	setProperties := func(properties map[string]*Property, pointer string) {
+		for name, property := range properties {
+			setPropertySourcePositions(property, pointer+JsonPointerReferenceTokenSeparator+EscapeJsonPointerReferenceToken(name), positions)
+		}
+	}
+
+	setProperties(r.Definitions, "/definitions")
+	setProperties(r.Properties, "/properties")
+	setSubschemaSourcePositions(r.AllOf, "/allOf", positions)
+	setSubschemaSourcePositions(r.AnyOf, "/anyOf", positions)
+	setSubschemaSourcePositions(r.OneOf, "/oneOf", positions)
+
+	for name, remote := range r.Remote {
+		if remote == nil {
+			continue
+		}
+
+		pointer := "/remote/" + EscapeJsonPointerReferenceToken(name)
+
+		setProperties(remote.Definitions, pointer+"/definitions")
+		setProperties(remote.Properties, pointer+"/properties")
+	}
+
+	for handlerType, handler := range r.Handlers {
+		if handler == nil {
+			continue
+		}
+
+		pointer := "/handlers/" + EscapeJsonPointerReferenceToken(handlerType)
+		handler.SourcePosition = positions[pointer]
+
+		if handler.HandlerSchema != nil {
+			setProperties(handler.HandlerSchema.Properties, pointer+"/handlerSchema/properties")
+		}
+	}
+
+	if r.TypeConfiguration != nil {
+		setProperties(r.TypeConfiguration.Properties, "/typeConfiguration/properties")
+	}
+}
+
+// setPropertySourcePositions records the document locations on the Property and its nested properties.
+func setPropertySourcePositions(property *Property, pointer string, positions map[string]*SourcePosition) {
+	if property == nil {
+		return
+	}
+
+	property.SourcePosition = positions[pointer]
+
+	for name, p := range property.Properties {
+		setPropertySourcePositions(p, pointer+"/properties/"+EscapeJsonPointerReferenceToken(name), positions)
+	}
+
+	for name, p := range property.PatternProperties {
+		setPropertySourcePositions(p, pointer+"/patternProperties/"+EscapeJsonPointerReferenceToken(name), positions)
+	}
+
+	for name, dependency := range property.Dependencies {
+		if dependency != nil {
+			setPropertySourcePositions(dependency.Schema, pointer+"/dependencies/"+EscapeJsonPointerReferenceToken(name), positions)
+		}
+	}
+
+	setPropertySourcePositions(property.Contains, pointer+"/contains", positions)
+	setPropertySourcePositions(property.Items, pointer+"/items", positions)
+	setPropertySourcePositions(property.Not, pointer+"/not", positions)
+	setPropertySourcePositions(property.PropertyNames, pointer+"/propertyNames", positions)
+	setSubschemaSourcePositions(property.AllOf, pointer+"/allOf", positions)
+	setSubschemaSourcePositions(property.AnyOf, pointer+"/anyOf", positions)
+	setSubschemaSourcePositions(property.OneOf, pointer+"/oneOf", positions)
+}
+
+// setSubschemaSourcePositions records the document locations on the properties of the subschemas.
+func setSubschemaSourcePositions(subschemas []*PropertySubschema, pointer string, positions map[string]*SourcePosition) {
+	for i, subschema := range subschemas {
+		if subschema == nil {
+			continue
+		}
+
+		subschemaPointer := pointer + JsonPointerReferenceTokenSeparator + strconv.Itoa(i)
+
+		for name, p := range subschema.Properties {
+			setPropertySourcePositions(p, subschemaPointer+"/properties/"+EscapeJsonPointerReferenceToken(name), positions)
+		}
+
+		setSubschemaSourcePositions(subschema.AllOf, subschemaPointer+"/allOf", positions)
+		setSubschemaSourcePositions(subschema.AnyOf, subschemaPointer+"/anyOf", positions)
+		setSubschemaSourcePositions(subschema.OneOf, subschemaPointer+"/oneOf", positions)
+	}
+}
+
+// newSourcePositions returns the location of every value in the JSON document keyed by RFC 6901 JSON Pointer,
+// or nil if the document is not valid JSON.
+func newSourcePositions(source []byte, filename string) map[string]*SourcePosition {
+	s := &sourcePositionScanner{
+		dec:       json.NewDecoder(bytes.NewReader(source)),
+		filename:  filename,
+		lines:     []int{0},
+		positions: make(map[string]*SourcePosition),
+		source:    source,
+	}
+
+	for i, c := range source {
+		if c == '\n' {
+			s.lines = append(s.lines, i+1)
+		}
+	}
+
+	if err := s.scan("", s.position()); err != nil {
+		return nil
+	}
+
+	return s.positions
+}
+
+// sourcePositionScanner records the locations of values while reading a JSON document token by token.
+type sourcePositionScanner struct {
+	dec       *json.Decoder
+	filename  string
+	lines     []int
+	positions map[string]*SourcePosition
+	source    []byte
+}
+
+// position returns the location of the next token.
+func (s *sourcePositionScanner) position() *SourcePosition {
+	offset := int(s.dec.InputOffset())
+
+	// Skip whitespace and any separator not yet consumed by the decoder.
+	for offset < len(s.source) {
+		switch s.source[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+			continue
+		}
+
+		break
+	}
+
+	line := sort.Search(len(s.lines), func(i int) bool { return s.lines[i] > offset })
+
+	return &SourcePosition{
+		Column:   offset - s.lines[line-1] + 1,
+		Filename: s.filename,
+		Line:     line,
+		Offset:   offset,
+	}
+}
+
+// scan records the location of the value at the pointer, and of all its nested values.
+func (s *sourcePositionScanner) scan(pointer string, position *SourcePosition) error {
+	s.positions[pointer] = position
+
+	token, err := s.dec.Token()
+
+	if err != nil {
+		return err
+	}
+
+	delim, ok := token.(json.Delim)
+
+	if !ok {
+		return nil
+	}
+
+	switch delim {
+	case '{':
+		for s.dec.More() {
+			position := s.position()
+			token, err := s.dec.Token()
+
+			if err != nil {
+				return err
+			}
+
+			key, ok := token.(string)
+
+			if !ok {
+				return fmt.Errorf("unexpected object key: %v", token)
+			}
+
+			if err := s.scan(pointer+JsonPointerReferenceTokenSeparator+EscapeJsonPointerReferenceToken(key), position); err != nil {
+				return err
+			}
+		}
+	case '[':
+		for i := 0; s.dec.More(); i++ {
+			if err := s.scan(pointer+JsonPointerReferenceTokenSeparator+strconv.Itoa(i), s.position()); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Closing delimiter.
+	_, err = s.dec.Token()
+
+	return err
+}
@@ -0,0 +1,177 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+const sourcePositionTestSchema = `{
+  "typeName": "Initech::TPS::Report",
+  "definitions": {
+    "Tag": {
+      "type": "object",
+      "properties": {
+        "Key": {"type": "string"}
+      }
+    }
+  },
+  "properties": {
+    "Id": {"type": "string"},
+    "Tags": {
+      "type": "array",
+      "items": {"$ref": "#/definitions/Tag"}
+    }
+  },
+  "readOnlyProperties": [
+    "/properties/Id"
+  ],
+  "handlers": {
+    "read": {"permissions": []}
+  }
+}`
+
+func TestResourceSourcePosition(t *testing.T) {
+	resourceSchema, err := cfschema.NewResourceJsonSchemaDocument(sourcePositionTestSchema)
+
+	if err != nil {
+		t.Fatalf("unexpected NewResourceJsonSchemaDocument() error: %s", err)
+	}
+
+	resource, err := resourceSchema.Resource()
+
+	if err != nil {
+		t.Fatalf("unexpected Resource() error: %s", err)
+	}
+
+	testCases := []struct {
+		TestDescription string
+		Position        func(*cfschema.Resource) *cfschema.SourcePosition
+		Expected        string
+	}{
+		{
+			TestDescription: "document",
+			Position:        func(r *cfschema.Resource) *cfschema.SourcePosition { return r.SourcePosition("") },
+			Expected:        "1:1",
+		},
+		{
+			TestDescription: "definition",
+			Position:        func(r *cfschema.Resource) *cfschema.SourcePosition { return r.Definitions["Tag"].SourcePosition },
+			Expected:        "4:5",
+		},
+		{
+			TestDescription: "nested property",
+			Position: func(r *cfschema.Resource) *cfschema.SourcePosition {
+				return r.Definitions["Tag"].Properties["Key"].SourcePosition
+			},
+			Expected: "7:9",
+		},
+		{
+			TestDescription: "property",
+			Position:        func(r *cfschema.Resource) *cfschema.SourcePosition { return r.Properties["Id"].SourcePosition },
+			Expected:        "12:5",
+		},
+		{
+			TestDescription: "items",
+			Position:        func(r *cfschema.Resource) *cfschema.SourcePosition { return r.Properties["Tags"].Items.SourcePosition },
+			Expected:        "15:7",
+		},
+		{
+			TestDescription: "pointer list entry",
+			Position:        func(r *cfschema.Resource) *cfschema.SourcePosition { return r.SourcePosition("/readOnlyProperties/0") },
+			Expected:        "19:5",
+		},
+		{
+			TestDescription: "handler",
+			Position:        func(r *cfschema.Resource) *cfschema.SourcePosition { return r.Handlers["read"].SourcePosition },
+			Expected:        "22:5",
+		},
+		{
+			TestDescription: "unknown",
+			Position:        func(r *cfschema.Resource) *cfschema.SourcePosition { return r.SourcePosition("/missing") },
+			Expected:        "",
+		},
+		{
+			TestDescription: "expanded items",
+			Position: func(r *cfschema.Resource) *cfschema.SourcePosition {
+				expanded, err := r.Expanded()
+
+				if err != nil {
+					t.Fatalf("unexpected Expanded() error: %s", err)
+				}
+
+				return expanded.Properties["Tags"].Items.SourcePosition
+			},
+			Expected: "15:7",
+		},
+		{
+			TestDescription: "expanded nested property",
+			Position: func(r *cfschema.Resource) *cfschema.SourcePosition {
+				expanded, err := r.Expanded()
+
+				if err != nil {
+					t.Fatalf("unexpected Expanded() error: %s", err)
+				}
+
+				return expanded.Properties["Tags"].Items.Properties["Key"].SourcePosition
+			},
+			Expected: "7:9",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			if actual, expected := testCase.Position(resource).String(), testCase.Expected; actual != expected {
+				t.Errorf("expected position (%s), got: %s", expected, actual)
+			}
+		})
+	}
+}
+
+func TestResourceSourcePosition_Path(t *testing.T) {
+	resource := loadAndValidateResourceSchema(t, "provider.definition.schema.v1.json", "initech.tps.report.v1.json")
+	position := resource.Properties["Title"].SourcePosition
+
+	if position == nil {
+		t.Fatal("expected position, got none")
+	}
+
+	if expected := filepath.Join("testdata", "initech.tps.report.v1.json"); position.Filename != expected {
+		t.Errorf("expected filename (%s), got: %s", expected, position.Filename)
+	}
+
+	if position.Line <= 1 || position.Column <= 1 {
+		t.Errorf("expected position within document, got: %s", position)
+	}
+}
+
+func TestValidationErrorPosition(t *testing.T) {
+	resourceSchema, err := cfschema.NewResourceJsonSchemaPath(filepath.Join("testdata", "initech.tps.report.v1.json"))
+
+	if err != nil {
+		t.Fatalf("unexpected NewResourceJsonSchemaPath() error: %s", err)
+	}
+
+	err = resourceSchema.ValidateConfigurationDocument("{\n  \"TestCode\": \"NOT_STARTED\",\n  \"Title\": 1\n}")
+
+	var validationErrors cfschema.ValidationErrors
+
+	if !errors.As(err, &validationErrors) {
+		t.Fatalf("expected ValidationErrors, got: %v", err)
+	}
+
+	if len(validationErrors) != 1 {
+		t.Fatalf("expected 1 error, got: %s", validationErrors)
+	}
+
+	if actual, expected := validationErrors[0].Position.String(), "3:3"; actual != expected {
+		t.Errorf("expected position (%s), got: %s", expected, actual)
+	}
+}
@@ -3,6 +3,10 @@
 
 package cfschema
 
+import (
+	"encoding/json"
+)
+
 type Tagging struct {
 	Taggable                 *bool                `json:"taggable,omitempty"`
 	TagOnCreate              *bool                `json:"tagOnCreate,omitempty"`
@@ -10,4 +14,38 @@ type Tagging struct {
 	CloudFormationSystemTags *bool                `json:"cloudFormationSystemTags,omitempty"`
 	TagProperty              *PropertyJsonPointer `json:"tagProperty,omitempty"`
 	Permissions              []string             `json:"permissions,omitempty"`
+	EmptyValues              Extensions           `json:"-"`
+	Extensions               Extensions           `json:"-"`
+}
+
+// MarshalJSON is a custom JSON handler for Tagging that preserves Extensions.
+func (t Tagging) MarshalJSON() ([]byte, error) {
+	type tagging Tagging
+
+	b, err := json.Marshal(tagging(t))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalExtensions(b, t.Extensions, t.EmptyValues)
+}
+
+// UnmarshalJSON is a custom JSON handler for Tagging that captures Extensions.
+func (t *Tagging) UnmarshalJSON(b []byte) error {
+	type tagging Tagging
+
+	var v tagging
+
+	extensions, emptyValues, err := unmarshalExtensions(b, &v)
+
+	if err != nil {
+		return err
+	}
+
+	*t = Tagging(v)
+	t.EmptyValues = emptyValues
+	t.Extensions = extensions
+
+	return nil
 }
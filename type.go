@@ -6,17 +6,114 @@ package cfschema
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
+// Type represents the JSON Schema type keyword.
+//
+// A single type, e.g. "string", is represented by its name, so it can be compared directly
+// with the PropertyType constants. A list of types, e.g. ["string", "null"], is represented by
+// its compact JSON form, so that it is marshalled back to its original form. String and Primary return
+// a single type name of either form; use Types, Includes and IsNullable for all the type names.
 type Type string
 
-// String is a string representation of Type.
-func (t *Type) String() string {
-	if t == nil {
+// NewType returns a Type for the provided type names. A single name returns a single type.
+func NewType(types ...string) Type {
+	switch len(types) {
+	case 0:
 		return ""
+	case 1:
+		return Type(types[0])
+	}
+
+	b, _ := json.Marshal(types)
+
+	return Type(b)
+}
+
+// Includes returns true if the provided type name is one of the types.
+func (t *Type) Includes(typ string) bool {
+	for _, v := range t.Types() {
+		if v == typ {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsList returns true if the type was declared as a list of types.
+func (t *Type) IsList() bool {
+	if t == nil {
+		return false
+	}
+
+	return strings.HasPrefix(string(*t), "[")
+}
+
+// IsNullable returns true if the types include null.
+func (t *Type) IsNullable() bool {
+	return t.Includes(PropertyTypeNull)
+}
+
+// Primary returns the first type name other than null, or null if that is the only type.
+// Returns an empty string if there are no types.
+func (t *Type) Primary() string {
+	types := t.Types()
+
+	for _, v := range types {
+		if v != PropertyTypeNull {
+			return v
+		}
+	}
+
+	if len(types) > 0 {
+		return types[0]
+	}
+
+	return ""
+}
+
+// String returns a single type name, so that a list of types is switched on like a single type.
+// As before lists of types were represented, this is the first type name other than object,
+// e.g. string for ["object", "string"], or object if that is the only type. Use Primary for the
+// first type name other than null.
+func (t *Type) String() string {
+	for _, v := range t.Types() {
+		if v != PropertyTypeObject {
+			return v
+		}
+	}
+
+	return t.Primary()
+}
+
+// Types returns the type names.
+func (t *Type) Types() []string {
+	if t == nil || *t == "" {
+		return nil
+	}
+
+	if !t.IsList() {
+		return []string{string(*t)}
+	}
+
+	var types []string
+
+	if err := json.Unmarshal([]byte(*t), &types); err != nil {
+		return nil
+	}
+
+	return types
+}
+
+// MarshalJSON is a custom JSON handler for Type that preserves its original form.
+func (t Type) MarshalJSON() ([]byte, error) {
+	if t.IsList() {
+		return []byte(t), nil
 	}
 
-	return string(*t)
+	return json.Marshal(string(t))
 }
 
 // UnmarshalJSON is a custom JSON handler for Type.
@@ -25,31 +122,32 @@ func (t *Type) UnmarshalJSON(b []byte) error {
 
 	err := json.Unmarshal(b, &tmp)
 
-	if err != nil {
-		var tmpTypes []string
+	if err == nil {
+		*t = Type(tmp)
 
-		err := json.Unmarshal(b, &tmpTypes)
+		return nil
+	}
 
-		if err != nil {
-			return err
-		}
+	var tmpTypes []string
 
-		if len(tmpTypes) != 2 {
-			return fmt.Errorf("type arrays with less or more than 2 elements are not supported")
-		}
+	err = json.Unmarshal(b, &tmpTypes)
 
-		for _, tmpType := range tmpTypes {
-			if tmpType == PropertyTypeObject {
-				continue
-			}
+	if err != nil {
+		return err
+	}
+
+	if len(tmpTypes) == 0 {
+		return fmt.Errorf("type arrays must have at least 1 element")
+	}
 
-			*t = Type(tmpType)
+	// Keep the list form, even for a single element.
+	b, err = json.Marshal(tmpTypes)
 
-			return nil
-		}
+	if err != nil {
+		return err
 	}
 
-	*t = Type(tmp)
+	*t = Type(b)
 
 	return nil
 }
@@ -0,0 +1,33 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+// TypeConfiguration represents the configuration data for registry types.
+//
+// This configuration data is not passed through the resource properties in template.
+// Any References in type configuration properties are resolved against the Resource Definitions.
+type TypeConfiguration struct {
+	AdditionalProperties *bool                `json:"additionalProperties,omitempty"`
+	AllOf                []*PropertySubschema `json:"allOf,omitempty"`
+	AnyOf                []*PropertySubschema `json:"anyOf,omitempty"`
+	DeprecatedProperties PropertyJsonPointers `json:"deprecatedProperties,omitempty"`
+	Description          *string              `json:"description,omitempty"`
+	OneOf                []*PropertySubschema `json:"oneOf,omitempty"`
+	Properties           map[string]*Property `json:"properties,omitempty"`
+	Required             []string             `json:"required,omitempty"`
+}
+
+func (t *TypeConfiguration) IsRequired(name string) bool {
+	if t == nil {
+		return false
+	}
+
+	for _, req := range t.Required {
+		if req == name {
+			return true
+		}
+	}
+
+	return false
+}
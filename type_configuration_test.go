@@ -0,0 +1,101 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestTypeConfiguration(t *testing.T) {
+	testCases := []struct {
+		TestDescription    string
+		MetaSchemaPath     string
+		ResourceSchemaPath string
+		ExpectNil          bool
+		ExpectedProperties []string
+		ExpectedRequired   []string
+	}{
+		{
+			TestDescription:    "no typeConfiguration",
+			MetaSchemaPath:     "provider.definition.schema.v1.json",
+			ResourceSchemaPath: "initech.tps.report.v1.json",
+			ExpectNil:          true,
+		},
+		{
+			TestDescription:    "typeConfiguration",
+			MetaSchemaPath:     "provider.definition.schema.v1.json",
+			ResourceSchemaPath: "initech.tps.report.typeconfiguration.v1.json",
+			ExpectedProperties: []string{"InitechCredentials"},
+			ExpectedRequired:   []string{"InitechCredentials"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			resource := loadAndValidateResourceSchema(t, testCase.MetaSchemaPath, testCase.ResourceSchemaPath)
+
+			typeConfiguration := resource.TypeConfiguration
+
+			if typeConfiguration == nil {
+				if !testCase.ExpectNil {
+					t.Fatal("expected typeConfiguration, got none")
+				}
+
+				return
+			}
+
+			if testCase.ExpectNil {
+				t.Fatal("expected no typeConfiguration, got one")
+			}
+
+			if actual, expected := len(typeConfiguration.Properties), len(testCase.ExpectedProperties); actual != expected {
+				t.Errorf("expected %d properties, got: %d", expected, actual)
+			}
+
+			for _, propertyName := range testCase.ExpectedProperties {
+				if _, ok := typeConfiguration.Properties[propertyName]; !ok {
+					t.Errorf("expected property (%s), got none", propertyName)
+				}
+			}
+
+			for _, name := range testCase.ExpectedRequired {
+				if !typeConfiguration.IsRequired(name) {
+					t.Errorf("expected (%s) to be required", name)
+				}
+			}
+		})
+	}
+}
+
+func TestTypeConfigurationExpand(t *testing.T) {
+	resource := loadAndValidateResourceSchema(t, "provider.definition.schema.v1.json", "initech.tps.report.typeconfiguration.v1.json")
+
+	if err := resource.Expand(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	property := resource.TypeConfiguration.Properties["InitechCredentials"]
+
+	if property.Ref != nil {
+		t.Fatalf("expected no property ref, got: %s", property.Ref)
+	}
+
+	if actual, expected := property.Type.String(), cfschema.PropertyTypeObject; actual != expected {
+		t.Fatalf("expected property type (%s), got: %s", expected, actual)
+	}
+
+	nested, ok := property.Properties["ApplicationKey"]
+
+	if !ok {
+		t.Fatal("expected nested property (ApplicationKey), got none")
+	}
+
+	if actual, expected := nested.Type.String(), cfschema.PropertyTypeString; actual != expected {
+		t.Errorf("expected nested property type (%s), got: %s", expected, actual)
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestTypeUnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		TestDescription string
+		Input           string
+		ExpectError     bool
+		ExpectedTypes   []string
+		ExpectedPrimary string
+		ExpectedString  string
+		ExpectList      bool
+		ExpectNullable  bool
+	}{
+		{
+			TestDescription: "single",
+			Input:           `"string"`,
+			ExpectedTypes:   []string{cfschema.PropertyTypeString},
+			ExpectedPrimary: cfschema.PropertyTypeString,
+		},
+		{
+			TestDescription: "single element list",
+			Input:           `["integer"]`,
+			ExpectedTypes:   []string{cfschema.PropertyTypeInteger},
+			ExpectedPrimary: cfschema.PropertyTypeInteger,
+			ExpectList:      true,
+		},
+		{
+			TestDescription: "union",
+			Input:           `["string","number"]`,
+			ExpectedTypes:   []string{cfschema.PropertyTypeString, cfschema.PropertyTypeNumber},
+			ExpectedPrimary: cfschema.PropertyTypeString,
+			ExpectList:      true,
+		},
+		{
+			TestDescription: "nullable",
+			Input:           `["null","integer"]`,
+			ExpectedTypes:   []string{cfschema.PropertyTypeNull, cfschema.PropertyTypeInteger},
+			ExpectedPrimary: cfschema.PropertyTypeInteger,
+			ExpectedString:  cfschema.PropertyTypeNull,
+			ExpectList:      true,
+			ExpectNullable:  true,
+		},
+		{
+			TestDescription: "object union",
+			Input:           `["object","string","array"]`,
+			ExpectedTypes:   []string{cfschema.PropertyTypeObject, cfschema.PropertyTypeString, cfschema.PropertyTypeArray},
+			ExpectedPrimary: cfschema.PropertyTypeObject,
+			ExpectedString:  cfschema.PropertyTypeString,
+			ExpectList:      true,
+		},
+		{
+			TestDescription: "nullable object",
+			Input:           `["object","null"]`,
+			ExpectedTypes:   []string{cfschema.PropertyTypeObject, cfschema.PropertyTypeNull},
+			ExpectedPrimary: cfschema.PropertyTypeObject,
+			ExpectedString:  cfschema.PropertyTypeNull,
+			ExpectList:      true,
+			ExpectNullable:  true,
+		},
+		{
+			TestDescription: "null",
+			Input:           `"null"`,
+			ExpectedTypes:   []string{cfschema.PropertyTypeNull},
+			ExpectedPrimary: cfschema.PropertyTypeNull,
+			ExpectNullable:  true,
+		},
+		{
+			TestDescription: "empty list",
+			Input:           `[]`,
+			ExpectError:     true,
+		},
+		{
+			TestDescription: "invalid",
+			Input:           `1`,
+			ExpectError:     true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			var typ cfschema.Type
+
+			err := json.Unmarshal([]byte(testCase.Input), &typ)
+
+			if err != nil && !testCase.ExpectError {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err == nil && testCase.ExpectError {
+				t.Fatal("expected error, got none")
+			}
+
+			if err != nil {
+				return
+			}
+
+			if actual, expected := typ.Types(), testCase.ExpectedTypes; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("expected types %q, got: %q", expected, actual)
+			}
+
+			if actual, expected := typ.Primary(), testCase.ExpectedPrimary; actual != expected {
+				t.Errorf("expected primary type (%s), got: %s", expected, actual)
+			}
+
+			expectedString := testCase.ExpectedString
+
+			if expectedString == "" {
+				expectedString = testCase.ExpectedPrimary
+			}
+
+			if actual, expected := typ.String(), expectedString; actual != expected {
+				t.Errorf("expected string (%s), got: %s", expected, actual)
+			}
+
+			if actual, expected := typ.IsList(), testCase.ExpectList; actual != expected {
+				t.Errorf("expected list (%t), got: %t", expected, actual)
+			}
+
+			if actual, expected := typ.IsNullable(), testCase.ExpectNullable; actual != expected {
+				t.Errorf("expected nullable (%t), got: %t", expected, actual)
+			}
+
+			for _, v := range testCase.ExpectedTypes {
+				if !typ.Includes(v) {
+					t.Errorf("expected type to include (%s)", v)
+				}
+			}
+
+			if typ.Includes(cfschema.PropertyTypeBoolean) {
+				t.Errorf("unexpected type to include (%s)", cfschema.PropertyTypeBoolean)
+			}
+
+			b, err := json.Marshal(&cfschema.Property{Type: &typ})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if actual, expected := string(b), `{"type":`+testCase.Input+`}`; actual != expected {
+				t.Errorf("expected marshalled (%s), got: %s", expected, actual)
+			}
+		})
+	}
+}
+
+func TestNewType(t *testing.T) {
+	if actual, expected := cfschema.NewType(cfschema.PropertyTypeString), cfschema.Type(cfschema.PropertyTypeString); actual != expected {
+		t.Errorf("expected (%s), got: %s", expected, actual)
+	}
+
+	typ := cfschema.NewType(cfschema.PropertyTypeString, cfschema.PropertyTypeNull)
+
+	if actual, expected := typ.Types(), []string{cfschema.PropertyTypeString, cfschema.PropertyTypeNull}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected types %q, got: %q", expected, actual)
+	}
+
+	if typ := cfschema.NewType(); typ.Types() != nil {
+		t.Errorf("expected no types, got: %q", typ.Types())
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// validationErrorContextDelimiter is used to split JSON Schema validation contexts into reference tokens.
+// It cannot appear in an unescaped JSON object key.
+const validationErrorContextDelimiter = "\x00"
+
+// validationErrorKeywords maps JSON Schema library error types to JSON Schema keywords.
+var validationErrorKeywords = map[string]string{
+	"additional_property_not_allowed": "additionalProperties",
+	"array_max_items":                 "maxItems",
+	"array_max_properties":            "maxProperties",
+	"array_min_items":                 "minItems",
+	"array_min_properties":            "minProperties",
+	"array_no_additional_items":       "additionalItems",
+	"condition_else":                  "else",
+	"condition_then":                  "then",
+	"const":                           "const",
+	"contains":                        "contains",
+	"enum":                            "enum",
+	"false":                           "false",
+	"format":                          "format",
+	"invalid_property_name":           "propertyNames",
+	"invalid_property_pattern":        "patternProperties",
+	"invalid_type":                    "type",
+	"missing_dependency":              "dependencies",
+	"multiple_of":                     "multipleOf",
+	"number_all_of":                   "allOf",
+	"number_any_of":                   "anyOf",
+	"number_gt":                       "exclusiveMinimum",
+	"number_gte":                      "minimum",
+	"number_lt":                       "exclusiveMaximum",
+	"number_lte":                      "maximum",
+	"number_not":                      "not",
+	"number_one_of":                   "oneOf",
+	"pattern":                         "pattern",
+	"required":                        "required",
+	"string_gte":                      "minLength",
+	"string_lte":                      "maxLength",
+	"unique":                          "uniqueItems",
+}
+
+// ValidationError represents a single JSON Schema validation error.
+type ValidationError struct {
+	// Details contains keyword specific details, such as expected and actual values.
+	Details map[string]interface{}
+
+	// Keyword is the JSON Schema keyword that failed validation, e.g. required or maxLength.
+	Keyword string
+
+	// Message is the human readable description of the error.
+	Message string
+
+	// Pointer is the RFC 6901 JSON Pointer to the invalid value in the validated document.
+	// The empty string refers to the whole document.
+	Pointer string
+
+	// Position is the location of the invalid value in the validated document, if known.
+	Position *SourcePosition
+
+	// Type is the underlying JSON Schema library error type.
+	Type string
+
+	// Value is the invalid value.
+	Value interface{}
+
+	str string
+}
+
+// Error returns the string representation of the ValidationError.
+func (e *ValidationError) Error() string {
+	if e == nil {
+		return ""
+	}
+
+	return e.str
+}
+
+// ValidationErrors is a list of ValidationError.
+type ValidationErrors []*ValidationError
+
+// Error returns the newline separated string representations of all errors.
+func (es ValidationErrors) Error() string {
+	var lines []string
+
+	for _, e := range es {
+		lines = append(lines, e.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns the individual errors.
+func (es ValidationErrors) Unwrap() []error {
+	errs := make([]error, 0, len(es))
+
+	for _, e := range es {
+		errs = append(errs, e)
+	}
+
+	return errs
+}
+
+// newValidationError returns a ValidationError from a JSON Schema library result error.
+func newValidationError(resultError gojsonschema.ResultError) *ValidationError {
+	typ := resultError.Type()
+	keyword, ok := validationErrorKeywords[typ]
+
+	if !ok {
+		keyword = typ
+	}
+
+	var pointer string
+
+	if context := resultError.Context(); context != nil {
+		pointer = validationErrorPointer(context.String(validationErrorContextDelimiter))
+	}
+
+	return &ValidationError{
+		Details: resultError.Details(),
+		Keyword: keyword,
+		Message: resultError.Description(),
+		Pointer: pointer,
+		Type:    typ,
+		Value:   resultError.Value(),
+		str:     resultError.String(),
+	}
+}
+
+// validationErrorPointer converts a delimited JSON Schema library context into a JSON Pointer.
+func validationErrorPointer(context string) string {
+	tokens := strings.Split(context, validationErrorContextDelimiter)
+
+	// Skip the "(root)" token.
+	if len(tokens) > 0 {
+		tokens = tokens[1:]
+	}
+
+	return joinJsonPointer(tokens)
+}
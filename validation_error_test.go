@@ -0,0 +1,91 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"errors"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestValidationErrors(t *testing.T) {
+	testCases := []struct {
+		TestDescription string
+		Document        string
+		ExpectedErrors  []string
+	}{
+		{
+			TestDescription: "valid",
+			Document:        `{"TestCode": "NOT_STARTED", "Title": "Initech TPS Report, Q4"}`,
+		},
+		{
+			TestDescription: "required",
+			Document:        `{"TestCode": "NOT_STARTED"}`,
+			ExpectedErrors:  []string{" required"},
+		},
+		{
+			TestDescription: "nested",
+			Document:        `{"TestCode": "STARTED", "Title": "short", "Memo": {"Heading": 1}, "Authors": ["a", 2]}`,
+			ExpectedErrors:  []string{"/Authors/1 type", "/Memo/Heading type", "/TestCode enum", "/Title minLength"},
+		},
+	}
+
+	resourceSchema, err := cfschema.NewResourceJsonSchemaPath(filepath.Join("testdata", "initech.tps.report.v1.json"))
+
+	if err != nil {
+		t.Fatalf("unexpected NewResourceJsonSchemaPath() error: %s", err)
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			err := resourceSchema.ValidateConfigurationDocument(testCase.Document)
+
+			if err == nil {
+				if len(testCase.ExpectedErrors) > 0 {
+					t.Fatal("expected error, got none")
+				}
+
+				return
+			}
+
+			var validationErrors cfschema.ValidationErrors
+
+			if !errors.As(err, &validationErrors) {
+				t.Fatalf("expected ValidationErrors, got: %T", err)
+			}
+
+			var actual []string
+
+			for _, validationError := range validationErrors {
+				if validationError.Message == "" {
+					t.Errorf("expected message for (%s), got none", validationError.Pointer)
+				}
+
+				if !strings.Contains(err.Error(), validationError.Error()) {
+					t.Errorf("expected error string to contain (%s), got: %s", validationError.Error(), err.Error())
+				}
+
+				actual = append(actual, validationError.Pointer+" "+validationError.Keyword)
+			}
+
+			sort.Strings(actual)
+
+			if expected := testCase.ExpectedErrors; strings.Join(actual, ",") != strings.Join(expected, ",") {
+				t.Errorf("expected errors (%v), got: %v", expected, actual)
+			}
+
+			var validationError *cfschema.ValidationError
+
+			if !errors.As(err, &validationError) {
+				t.Errorf("expected ValidationError, got: %T", err)
+			}
+		})
+	}
+}
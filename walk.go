@@ -0,0 +1,293 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// SkipAll is returned by a Visitor to stop walking. Walk then returns nil.
+	SkipAll = errors.New("skip all remaining properties")
+
+	// SkipProperty is returned by a Visitor to skip the properties nested in the visited property.
+	SkipProperty = errors.New("skip nested properties")
+)
+
+// WalkProperty is a property visited by Walk.
+//
+// Pointer is the PropertyJsonPointer of the property, e.g. /properties/Tags/*/Key, with array items as the
+// * wildcard. Properties in definitions have no Pointer. Location is the RFC 6901 JSON Pointer to the property
+// in the resource schema document, e.g. /definitions/Tag/properties/Key, prefixed with the document of any
+// Reference to another document followed to the property.
+// The metadata flags are set if the property, or any property it is nested in, is listed in the
+// corresponding resource-level list, e.g. readOnlyProperties.
+type WalkProperty struct {
+	CreateOnly bool
+	Deprecated bool
+	Depth      int
+	Location   string
+	Parent     *Property
+	Pointer    PropertyJsonPointer
+	Property   *Property
+	ReadOnly   bool
+	WriteOnly  bool
+}
+
+// Visitor is called by Walk for each property.
+// Returning SkipProperty skips the nested properties, SkipAll stops walking and any other error stops walking and is returned by Walk.
+type Visitor func(*WalkProperty) error
+
+// Walk visits each property of the Resource, depth-first in name order: the Properties and all their nested
+// Properties, PatternProperties, Items and properties of subschemas, followed by the Definitions likewise.
+// Properties of allOf, anyOf and oneOf subschemas, including those of the Resource, are visited together
+// with any Properties alongside them, once per name.
+//
+// References are followed without modifying the Resource, so Walk may be called before or after Expand.
+// A property whose reference target is already being visited is visited, but not descended into again.
+func Walk(resource *Resource, visitor Visitor) error {
+	if resource == nil {
+		return nil
+	}
+
+	w := &walker{
+		resource:     resource,
+		visiting:     make(map[*Property]struct{}),
+		visitingRefs: make(map[Reference]struct{}),
+		visitor:      visitor,
+	}
+
+	properties, locations := walkProperties("", resource.Properties, resource.AllOf, resource.AnyOf, resource.OneOf)
+	err := w.walkProperties(properties, locations, &WalkProperty{
+		Pointer: NewPropertyJsonPointer(),
+	})
+
+	if err == nil {
+		err = w.walkProperties(resource.Definitions, walkLocations(ReferenceSeparator+ReferenceTypeDefinitions, resource.Definitions), &WalkProperty{})
+	}
+
+	if errors.Is(err, SkipAll) {
+		return nil
+	}
+
+	return err
+}
+
+// walker holds the state of a Walk.
+type walker struct {
+	resource     *Resource
+	visiting     map[*Property]struct{}
+	visitingRefs map[Reference]struct{}
+	visitor      Visitor
+}
+
+// walkProperties visits each property of a name-to-property map nested in the parent, at the locations by name.
+func (w *walker) walkProperties(properties map[string]*Property, locations map[string]string, parent *WalkProperty) error {
+	for _, name := range sortedPropertyNames(properties) {
+		var pointer PropertyJsonPointer
+
+		if parent.Pointer != "" {
+			pointer = PropertyJsonPointer(parent.Pointer.String() + ReferenceSeparator + EscapeJsonPointerReferenceToken(name))
+		}
+
+		if err := w.walkProperty(properties[name], pointer, locations[name], parent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walkProperty visits the property and then its nested properties.
+func (w *walker) walkProperty(property *Property, pointer PropertyJsonPointer, location string, parent *WalkProperty) error {
+	if property == nil {
+		return nil
+	}
+
+	r := w.resource
+	visit := &WalkProperty{
+		CreateOnly: parent.CreateOnly,
+		Deprecated: parent.Deprecated,
+		Depth:      parent.Depth + 1,
+		Location:   location,
+		Parent:     parent.Property,
+		Pointer:    pointer,
+		Property:   property,
+		ReadOnly:   parent.ReadOnly,
+		WriteOnly:  parent.WriteOnly,
+	}
+
+	if pointer != "" {
+		path := pointer.Path()
+
+		visit.CreateOnly = visit.CreateOnly || walkPointersContain(r.CreateOnlyProperties, path)
+		visit.Deprecated = visit.Deprecated || walkPointersContain(r.DeprecatedProperties, path)
+		visit.ReadOnly = visit.ReadOnly || walkPointersContain(r.ReadOnlyProperties, path)
+		visit.WriteOnly = visit.WriteOnly || walkPointersContain(r.WriteOnlyProperties, path)
+	}
+
+	err := w.visitor(visit)
+
+	if errors.Is(err, SkipProperty) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	resolved, ref := r.resolvedPropertyReference(property)
+
+	if resolved == nil {
+		return nil
+	}
+
+	if _, ok := w.visiting[resolved]; ok {
+		return nil
+	}
+
+	w.visiting[resolved] = struct{}{}
+	defer delete(w.visiting, resolved)
+
+	// Properties resolved from other documents are new on each resolution, so also track the references.
+	if ref := propertyReference(property); ref != nil {
+		if _, ok := w.visitingRefs[*ref]; ok {
+			return nil
+		}
+
+		w.visitingRefs[*ref] = struct{}{}
+		defer delete(w.visitingRefs, *ref)
+	}
+
+	if ref != nil {
+		location = walkReferenceLocation(*ref)
+	}
+
+	if resolved.Items != nil {
+		var itemsPointer PropertyJsonPointer
+
+		if pointer != "" {
+			itemsPointer = PropertyJsonPointer(pointer.String() + ReferenceSeparator + PropertyJsonPointerWildcard)
+		}
+
+		if err := w.walkProperty(resolved.Items, itemsPointer, location+"/items", visit); err != nil {
+			return err
+		}
+	}
+
+	if err := w.walkProperties(resolved.PatternProperties, walkLocations(location+"/patternProperties", resolved.PatternProperties), visit); err != nil {
+		return err
+	}
+
+	properties, locations := walkProperties(location, resolved.Properties, resolved.AllOf, resolved.AnyOf, resolved.OneOf)
+
+	return w.walkProperties(properties, locations, visit)
+}
+
+// walkProperties returns the properties of the object at location together with those of the allOf, anyOf
+// and oneOf subschemas, at any depth, keeping the first definition of each name, and their locations by name.
+func walkProperties(location string, properties map[string]*Property, allOf, anyOf, oneOf []*PropertySubschema) (map[string]*Property, map[string]string) {
+	var all map[string]*Property
+	var allLocations map[string]string
+
+	add := func(properties map[string]*Property, locations map[string]string) {
+		for name, property := range properties {
+			if _, ok := all[name]; ok {
+				continue
+			}
+
+			if all == nil {
+				all = make(map[string]*Property)
+				allLocations = make(map[string]string)
+			}
+
+			all[name] = property
+			allLocations[name] = locations[name]
+		}
+	}
+
+	add(properties, walkLocations(location+"/properties", properties))
+
+	for _, composition := range []struct {
+		keyword    string
+		subschemas []*PropertySubschema
+	}{
+		{"allOf", allOf},
+		{"anyOf", anyOf},
+		{"oneOf", oneOf},
+	} {
+		for i, subschema := range composition.subschemas {
+			if subschema != nil {
+				add(walkProperties(fmt.Sprintf("%s/%s/%d", location, composition.keyword, i), subschema.Properties, subschema.AllOf, subschema.AnyOf, subschema.OneOf))
+			}
+		}
+	}
+
+	return all, allLocations
+}
+
+// walkLocations returns the locations by name of the properties of a name-to-property map at location.
+func walkLocations(location string, properties map[string]*Property) map[string]string {
+	locations := make(map[string]string, len(properties))
+
+	for name := range properties {
+		locations[name] = location + ReferenceSeparator + EscapeJsonPointerReferenceToken(name)
+	}
+
+	return locations
+}
+
+// walkReferenceLocation returns the location of a Reference target, prefixed with the document of a
+// Reference to another document.
+func walkReferenceLocation(ref Reference) string {
+	document, fragment := ref.split()
+
+	if document == "" {
+		return fragment
+	}
+
+	return document + ReferenceAnchor + fragment
+}
+
+// walkPointersContain returns true if a pointer in the list matches the path, ignoring any wildcard array items.
+func walkPointersContain(ptrs PropertyJsonPointers, path []string) bool {
+	path = withoutWildcards(path)
+
+	for _, ptr := range ptrs {
+		ptrPath := withoutWildcards(ptr.Path())
+
+		if len(ptrPath) != len(path) {
+			continue
+		}
+
+		matches := true
+
+		for i := range path {
+			if ptrPath[i] != path[i] {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withoutWildcards returns the path tokens other than wildcard array items.
+func withoutWildcards(path []string) []string {
+	var tokens []string
+
+	for _, token := range path {
+		if token != PropertyJsonPointerWildcard {
+			tokens = append(tokens, token)
+		}
+	}
+
+	return tokens
+}
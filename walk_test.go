@@ -0,0 +1,245 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package cfschema_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	cfschema "github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go"
+)
+
+func TestWalk(t *testing.T) {
+	schema := `{
+		"typeName": "Initech::TPS::Report",
+		"definitions": {
+			"Tag": {"type": "object", "properties": {"Key": {"type": "string"}, "Value": {"type": "string"}}}
+		},
+		"properties": {
+			"Id": {"type": "string"},
+			"Memo": {"type": "object", "properties": {"Heading": {"type": "string"}, "Body": {"type": "string"}}},
+			"Tags": {"type": "array", "items": {"$ref": "#/definitions/Tag"}}
+		},
+		"readOnlyProperties": ["/properties/Id"],
+		"createOnlyProperties": ["/properties/Memo"],
+		"writeOnlyProperties": ["/properties/Tags/*/Value"],
+		"deprecatedProperties": ["/properties/Memo/Body"]
+	}`
+
+	walkString := func(v *cfschema.WalkProperty) string {
+		var flags string
+
+		for _, flag := range []struct {
+			name string
+			set  bool
+		}{{"c", v.CreateOnly}, {"d", v.Deprecated}, {"r", v.ReadOnly}, {"w", v.WriteOnly}} {
+			if flag.set {
+				flags += flag.name
+			}
+		}
+
+		return fmt.Sprintf("%d %s %s %s parent:%t", v.Depth, v.Location, v.Pointer, flags, v.Parent != nil)
+	}
+
+	testCases := []struct {
+		TestDescription string
+		Expand          bool
+		Skip            string
+		Stop            string
+		Expected        []string
+	}{
+		{
+			TestDescription: "all",
+			Expected: []string{
+				"1 /properties/Id /properties/Id r parent:false",
+				"1 /properties/Memo /properties/Memo c parent:false",
+				"2 /properties/Memo/properties/Body /properties/Memo/Body cd parent:true",
+				"2 /properties/Memo/properties/Heading /properties/Memo/Heading c parent:true",
+				"1 /properties/Tags /properties/Tags  parent:false",
+				"2 /properties/Tags/items /properties/Tags/*  parent:true",
+				"3 /definitions/Tag/properties/Key /properties/Tags/*/Key  parent:true",
+				"3 /definitions/Tag/properties/Value /properties/Tags/*/Value w parent:true",
+				"1 /definitions/Tag   parent:false",
+				"2 /definitions/Tag/properties/Key   parent:true",
+				"2 /definitions/Tag/properties/Value   parent:true",
+			},
+		},
+		{
+			TestDescription: "expanded",
+			Expand:          true,
+			Expected: []string{
+				"1 /properties/Id /properties/Id r parent:false",
+				"1 /properties/Memo /properties/Memo c parent:false",
+				"2 /properties/Memo/properties/Body /properties/Memo/Body cd parent:true",
+				"2 /properties/Memo/properties/Heading /properties/Memo/Heading c parent:true",
+				"1 /properties/Tags /properties/Tags  parent:false",
+				"2 /properties/Tags/items /properties/Tags/*  parent:true",
+				"3 /properties/Tags/items/properties/Key /properties/Tags/*/Key  parent:true",
+				"3 /properties/Tags/items/properties/Value /properties/Tags/*/Value w parent:true",
+				"1 /definitions/Tag   parent:false",
+				"2 /definitions/Tag/properties/Key   parent:true",
+				"2 /definitions/Tag/properties/Value   parent:true",
+			},
+		},
+		{
+			TestDescription: "skip",
+			Skip:            "/properties/Memo",
+			Expected: []string{
+				"1 /properties/Id /properties/Id r parent:false",
+				"1 /properties/Memo /properties/Memo c parent:false",
+				"1 /properties/Tags /properties/Tags  parent:false",
+				"2 /properties/Tags/items /properties/Tags/*  parent:true",
+				"3 /definitions/Tag/properties/Key /properties/Tags/*/Key  parent:true",
+				"3 /definitions/Tag/properties/Value /properties/Tags/*/Value w parent:true",
+				"1 /definitions/Tag   parent:false",
+				"2 /definitions/Tag/properties/Key   parent:true",
+				"2 /definitions/Tag/properties/Value   parent:true",
+			},
+		},
+		{
+			TestDescription: "stop",
+			Stop:            "/properties/Memo/properties/Heading",
+			Expected: []string{
+				"1 /properties/Id /properties/Id r parent:false",
+				"1 /properties/Memo /properties/Memo c parent:false",
+				"2 /properties/Memo/properties/Body /properties/Memo/Body cd parent:true",
+				"2 /properties/Memo/properties/Heading /properties/Memo/Heading c parent:true",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.TestDescription, func(t *testing.T) {
+			var resource cfschema.Resource
+
+			if err := json.Unmarshal([]byte(schema), &resource); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if testCase.Expand {
+				if err := resource.Expand(); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+
+			var actual []string
+
+			err := cfschema.Walk(&resource, func(v *cfschema.WalkProperty) error {
+				actual = append(actual, walkString(v))
+
+				switch v.Location {
+				case testCase.Skip:
+					return cfschema.SkipProperty
+				case testCase.Stop:
+					return cfschema.SkipAll
+				}
+
+				return nil
+			})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if expected := testCase.Expected; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("expected:\n%q\ngot:\n%q", expected, actual)
+			}
+		})
+	}
+}
+
+func TestWalk_Error(t *testing.T) {
+	resource := loadAndValidateResourceSchema(t, "provider.definition.schema.v1.json", "initech.tps.report.v1.json")
+	expected := errors.New("test")
+	visits := 0
+
+	err := cfschema.Walk(resource, func(v *cfschema.WalkProperty) error {
+		visits++
+
+		return expected
+	})
+
+	if !errors.Is(err, expected) {
+		t.Errorf("expected error (%s), got: %s", expected, err)
+	}
+
+	if visits != 1 {
+		t.Errorf("expected 1 visit, got: %d", visits)
+	}
+}
+
+func TestWalk_Recursive(t *testing.T) {
+	var resource cfschema.Resource
+
+	if err := json.Unmarshal([]byte(`{"typeName": "Initech::TPS::Report", "definitions": {"Node": {"type": "object", "properties": {"Child": {"$ref": "#/definitions/Node"}}}}, "properties": {"Root": {"$ref": "#/definitions/Node"}}}`), &resource); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var locations []string
+
+	err := cfschema.Walk(&resource, func(v *cfschema.WalkProperty) error {
+		locations = append(locations, v.Location)
+
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := []string{"/properties/Root", "/definitions/Node/properties/Child", "/definitions/Node", "/definitions/Node/properties/Child"}; !reflect.DeepEqual(locations, expected) {
+		t.Errorf("expected %q, got: %q", expected, locations)
+	}
+}
+
+func TestWalk_Subschemas(t *testing.T) {
+	var resource cfschema.Resource
+
+	if err := json.Unmarshal([]byte(`{
+		"typeName": "Initech::TPS::Report",
+		"properties": {
+			"Id": {"type": "string"},
+			"Source": {
+				"type": "object",
+				"properties": {"Format": {"type": "string"}},
+				"oneOf": [
+					{"properties": {"Bucket": {"type": "string"}}, "required": ["Bucket"]},
+					{"allOf": [{"properties": {"Inline": {"type": "string"}}}]}
+				]
+			}
+		},
+		"anyOf": [
+			{"properties": {"Name": {"type": "string"}}, "required": ["Name"]},
+			{"properties": {"Id": {"type": "string"}}, "required": ["Id"]}
+		]
+	}`), &resource); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var pointers, locations []string
+
+	err := cfschema.Walk(&resource, func(v *cfschema.WalkProperty) error {
+		pointers = append(pointers, v.Pointer.String())
+		locations = append(locations, v.Location)
+
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := []string{"/properties/Id", "/properties/Name", "/properties/Source", "/properties/Source/Bucket", "/properties/Source/Format", "/properties/Source/Inline"}; !reflect.DeepEqual(pointers, expected) {
+		t.Errorf("expected %q, got: %q", expected, pointers)
+	}
+
+	if expected := []string{"/properties/Id", "/anyOf/0/properties/Name", "/properties/Source", "/properties/Source/oneOf/0/properties/Bucket", "/properties/Source/properties/Format", "/properties/Source/oneOf/1/allOf/0/properties/Inline"}; !reflect.DeepEqual(locations, expected) {
+		t.Errorf("expected %q, got: %q", expected, locations)
+	}
+}